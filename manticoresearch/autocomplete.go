@@ -0,0 +1,226 @@
+package manticoresearch
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+/*
+Autocomplete
+Via: https://manual.manticoresearch.com/Searching/Autocomplete
+
+CALL AUTOCOMPLETE (requires Manticore Buddy) completes the last word of the query, fixes keyboard layout and typos:
+-> CALL AUTOCOMPLETE('hello wor', 'table', 1 as fuzziness, 'us,ru' as layouts)
+
+Without buddy, the last word is expanded with CALL KEYWORDS (requires min_prefix_len or min_infix_len and dict=keywords):
+-> CALL KEYWORDS('wor*', 'table', 1 as stats, 0 as fold_wildcards, 'docs' as sort_mode, 10 as expansion_limit)
+
+Suggestions are ranked by docs count in the fallback mode, in server order otherwise.
+*/
+const DefaultMcAutocompleteLimit = 10
+
+const (
+	McAutocompleteSourceAutocomplete = "autocomplete"
+	McAutocompleteSourceKeywords     = "keywords"
+)
+
+type McAutocompleteOptions struct {
+	// Maximum number of suggestions. Default: 10
+	Limit int
+
+	// Typo tolerance: 0, 1 or 2 edits. Default: 2 on server
+	Fuzziness *int
+
+	// Keyboard layouts for layout fix: "us,ru". Empty: all layouts, "none": disable
+	Layouts string
+
+	// Expand words shorter than this length (CALL AUTOCOMPLETE expansion_len)
+	ExpansionLen int
+
+	// Use CALL KEYWORDS only
+	KeywordsOnly bool
+}
+
+func NewMcAutocompleteOptions() McAutocompleteOptions {
+	return McAutocompleteOptions{
+		Limit: DefaultMcAutocompleteLimit,
+	}
+}
+
+func (qb McAutocompleteOptions) AddLimit(limit int) McAutocompleteOptions {
+	qb.Limit = limit
+
+	return qb
+}
+
+func (qb McAutocompleteOptions) AddFuzziness(fuzziness int) McAutocompleteOptions {
+	qb.Fuzziness = &fuzziness
+
+	return qb
+}
+
+func (qb McAutocompleteOptions) AddLayouts(layouts ...string) McAutocompleteOptions {
+	qb.Layouts = strings.Join(layouts, ",")
+
+	return qb
+}
+
+func (qb McAutocompleteOptions) AddExpansionLen(length int) McAutocompleteOptions {
+	qb.ExpansionLen = length
+
+	return qb
+}
+
+func (qb McAutocompleteOptions) AddKeywordsOnly(enabled bool) McAutocompleteOptions {
+	qb.KeywordsOnly = enabled
+
+	return qb
+}
+
+type McAutocompleteSuggestion struct {
+	Suggestion string
+
+	// keywords mode stats
+	Docs int64
+	Hits int64
+
+	// autocomplete or keywords
+	Source string
+}
+
+func (m *ManticoreClient) Autocomplete(ctx context.Context, tableName string, prefix string, opt McAutocompleteOptions) ([]McAutocompleteSuggestion, error) {
+	if opt.Limit <= 0 {
+		opt.Limit = DefaultMcAutocompleteLimit
+	}
+
+	prefix = strings.TrimSpace(prefix)
+	if prefix == "" {
+		return []McAutocompleteSuggestion{}, nil
+	}
+
+	if !opt.KeywordsOnly {
+		suggestions, err := m.callAutocomplete(ctx, tableName, prefix, opt)
+		if err == nil {
+			return suggestions, nil
+		}
+
+		// fallback only when buddy is missing, other errors (ctx, table, syntax) are returned as-is
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		if !autocompleteUnsupported(err) {
+			return nil, err
+		}
+
+		if m.client.debug {
+			fmt.Printf("CALL AUTOCOMPLETE unsupported, fallback to CALL KEYWORDS: %s\n", err)
+		}
+	}
+
+	return m.keywordsAutocomplete(ctx, tableName, prefix, opt)
+}
+
+// Without buddy searchd rejects the procedure itself:
+// "no such built-in procedure AUTOCOMPLETE", "unknown procedure", ...
+func autocompleteUnsupported(err error) bool {
+	msg := strings.ToLower(err.Error())
+
+	for _, pattern := range []string{"no such built-in procedure", "no such builtin procedure", "unknown procedure", "buddy"} {
+		if strings.Contains(msg, pattern) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *ManticoreClient) callAutocomplete(ctx context.Context, tableName string, prefix string, opt McAutocompleteOptions) ([]McAutocompleteSuggestion, error) {
+	args := []string{sqlQuote(prefix), sqlQuote(tableName)}
+
+	if opt.Fuzziness != nil {
+		args = append(args, fmt.Sprintf("%d as fuzziness", *opt.Fuzziness))
+	}
+
+	if opt.Layouts != "" {
+		args = append(args, fmt.Sprintf("%s as layouts", sqlQuote(opt.Layouts)))
+	}
+
+	if opt.ExpansionLen > 0 {
+		args = append(args, fmt.Sprintf("%d as expansion_len", opt.ExpansionLen))
+	}
+
+	resp, err := m.RunCliContext(ctx, []byte(fmt.Sprintf("CALL AUTOCOMPLETE(%s)", strings.Join(args, ", "))))
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions := []McAutocompleteSuggestion{}
+	for _, row := range resp.Rows() {
+		if len(suggestions) >= opt.Limit {
+			break
+		}
+
+		suggestions = append(suggestions, McAutocompleteSuggestion{
+			Suggestion: rowString(row, "query"),
+			Source:     McAutocompleteSourceAutocomplete,
+		})
+	}
+
+	return suggestions, nil
+}
+
+// Expand the last word of prefix, keep the leading words
+func (m *ManticoreClient) keywordsAutocomplete(ctx context.Context, tableName string, prefix string, opt McAutocompleteOptions) ([]McAutocompleteSuggestion, error) {
+	words := strings.Fields(prefix)
+	head := strings.Join(words[:len(words)-1], " ")
+	last := strings.TrimRight(words[len(words)-1], "*")
+
+	kwOpt := NewMcKeywordsOptions().
+		AddStats(true).
+		AddFoldWildcards(false).
+		AddSortMode(McKeywordsSortModeDocs).
+		AddExpansionLimit(opt.Limit)
+
+	keywords, err := m.Keywords(ctx, tableName, last+"*", kwOpt)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	suggestions := []McAutocompleteSuggestion{}
+	for _, keyword := range keywords {
+		word := keyword.Normalized
+		if word == "" || strings.Contains(word, "*") || seen[word] {
+			continue
+		}
+		seen[word] = true
+
+		if head != "" {
+			word = head + " " + word
+		}
+
+		suggestions = append(suggestions, McAutocompleteSuggestion{
+			Suggestion: word,
+			Docs:       keyword.Docs,
+			Hits:       keyword.Hits,
+			Source:     McAutocompleteSourceKeywords,
+		})
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		if suggestions[i].Docs != suggestions[j].Docs {
+			return suggestions[i].Docs > suggestions[j].Docs
+		}
+
+		return suggestions[i].Hits > suggestions[j].Hits
+	})
+
+	if len(suggestions) > opt.Limit {
+		suggestions = suggestions[:opt.Limit]
+	}
+
+	return suggestions, nil
+}
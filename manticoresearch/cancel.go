@@ -0,0 +1,93 @@
+package manticoresearch
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+/*
+Kill On Cancel
+
+Closing the HTTP connection does not stop a running search, searchd keeps working on it.
+With RegisterMCKillOnCancel every search with a cancellable context is tagged by a query comment:
+
+	"options": { "comment": "mc-cancel-3f2a9c1e7b4d0a56" }
+
+When the context is cancelled before the response arrives, the tagged query is looked up in SHOW QUERIES
+and killed with KILL <id>. Both statements are sent to the VIP listener: it accepts connections even when all
+worker threads are busy, exactly the situation where abandoned searches pile up.
+
+Killing an own tagged search is allowed in readonly mode.
+*/
+const (
+	mcCancelTagPrefix   = "mc-cancel-"
+	mcCancelKillTimeout = 5 * time.Second
+)
+
+// Copy of builder with unique comment tag
+func tagSearch(builder *McSearchQueryBuilder) (*McSearchQueryBuilder, string) {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return builder, ""
+	}
+
+	tag := mcCancelTagPrefix + hex.EncodeToString(id)
+
+	tagged := *builder
+	options := McOptions{}
+	if builder.Options != nil {
+		options = *builder.Options
+	}
+
+	if options.Comment != "" {
+		options.Comment = fmt.Sprintf("%s %s", options.Comment, tag)
+	} else {
+		options.Comment = tag
+	}
+	tagged.Options = &options
+
+	return &tagged, tag
+}
+
+// Client for VIP listener
+func (m *ManticoreClient) vip() *ManticoreClient {
+	vip := *m
+	if m.vipUrl != "" {
+		vip.url = m.vipUrl
+	}
+
+	return &vip
+}
+
+// Find tagged query in SHOW QUERIES and kill it
+func (m *ManticoreClient) killTaggedQuery(tag string) {
+	ctx, cancel := context.WithTimeout(context.Background(), mcCancelKillTimeout)
+	defer cancel()
+
+	vip := m.vip()
+
+	queries, err := vip.GetQueries(ctx)
+	if err != nil {
+		if m.client.debug {
+			fmt.Printf("Kill on cancel: %s - %v\n", tag, err)
+		}
+
+		return
+	}
+
+	for _, q := range queries {
+		if !strings.Contains(q.Query, tag) {
+			continue
+		}
+
+		// bypass readonly check of KillQuery: own query
+		_, err := vip.RunCliRawContext(ctx, []byte(fmt.Sprintf("KILL %d", q.ID)))
+		if m.client.debug {
+			fmt.Printf("Kill on cancel: %s - query %d - %v\n", tag, q.ID, err)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package manticoresearch
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -44,6 +45,15 @@ func RegisterMCApiSettings(url string, readOnly bool) MCOption {
 	}
 }
 
+// Kill server side search on context cancellation, through the VIP listener (listen = 9310:http_vip).
+// Empty vipUrl: main url
+func RegisterMCKillOnCancel(vipUrl string) MCOption {
+	return func(m *ManticoreClient) {
+		m.killOnCancel = true
+		m.vipUrl = vipUrl
+	}
+}
+
 // Manticore Client Constants
 const DefaultMCName = "MyManticoreBot"
 const (
@@ -55,6 +65,7 @@ const (
 	MCApiRouteReplace = "replace"
 	MCApiRouteDelete  = "delete"
 	MCApiRouteSearch  = "search"
+	MCApiRoutePq      = "pq"
 )
 
 type ManticoreClient struct {
@@ -64,10 +75,19 @@ type ManticoreClient struct {
 	readOnly bool
 
 	client *HttpClient
+
+	// replicated tables: table -> cluster
+	clusters *mcClusterRegistry
+
+	// kill cancelled searches through VIP listener
+	killOnCancel bool
+	vipUrl       string
 }
 
 func NewManticoreClient(options ...MCOption) *ManticoreClient {
-	a := &ManticoreClient{}
+	a := &ManticoreClient{
+		clusters: &mcClusterRegistry{tables: map[string]string{}},
+	}
 
 	for _, opt := range options {
 		opt(a)
@@ -94,7 +114,10 @@ func (m ManticoreClient) generateUrl(args []string) string {
 
 // Info
 func (m *ManticoreClient) Info() (resp *McInfoResponse, err error) {
-	code, body, err := m.client.Get(m.generateUrl([]string{}))
+	return m.InfoContext(context.Background())
+}
+func (m *ManticoreClient) InfoContext(ctx context.Context) (resp *McInfoResponse, err error) {
+	code, body, err := m.client.GetContext(ctx, m.generateUrl([]string{}))
 	if err != nil {
 		return nil, err
 	}
@@ -130,7 +153,10 @@ Endpoint: POST /cli
 The /cli endpoint accepts any SQL query and returns the response in raw format, similar to what you would receive via mysql. Unlike the /sql and /sql?mode=raw endpoints, the query parameter should not be URL-encoded. This endpoint is intended for manual actions using a browser or command line HTTP clients such as curl. It is not recommended to use the /cli endpoint in scripts.
 */
 func (m *ManticoreClient) RunCli(payload []byte) (resp *MCDocumentMainResponse, err error) {
-	code, body, err := m.client.Post(m.generateUrl([]string{MCApiRouteCli}), payload)
+	return m.RunCliContext(context.Background(), payload)
+}
+func (m *ManticoreClient) RunCliContext(ctx context.Context, payload []byte) (resp *MCDocumentMainResponse, err error) {
+	code, body, err := m.client.PostContext(ctx, m.generateUrl([]string{MCApiRouteCli}), payload)
 	if err != nil {
 		return nil, err
 	}
@@ -161,7 +187,10 @@ func (m *ManticoreClient) RunCli(payload []byte) (resp *MCDocumentMainResponse,
 
 // return only unknown interface
 func (m *ManticoreClient) RunCliRaw(payload []byte) (resp *interface{}, err error) {
-	code, body, err := m.client.Post(m.generateUrl([]string{MCApiRouteCli}), payload)
+	return m.RunCliRawContext(context.Background(), payload)
+}
+func (m *ManticoreClient) RunCliRawContext(ctx context.Context, payload []byte) (resp *interface{}, err error) {
+	code, body, err := m.client.PostContext(ctx, m.generateUrl([]string{MCApiRouteCli}), payload)
 	if err != nil {
 		return nil, err
 	}
@@ -196,7 +225,7 @@ func (m *ManticoreClient) ShowTableStatus(tableName string) (resp *MCDocumentMai
 
 // https://manual.manticoresearch.com/Creating_a_table/Local_tables/Plain_and_real-time_table_settings#How-to-change-rt_mem_limit-and-optimize_cutoff
 func (m *ManticoreClient) ReconfigureTable(tableName string) (resp *MCDocumentMainResponse, err error) {
-	return m.RunCli([]byte(fmt.Sprintf("ALTER TABLE %s RECONFİGURE", tableName)))
+	return m.RunCli([]byte(fmt.Sprintf("ALTER TABLE %s RECONFIGURE", tableName)))
 }
 
 func (m *ManticoreClient) DescTable(tableName string) (resp *MCDocumentMainResponse, err error) {
@@ -217,24 +246,23 @@ func (m *ManticoreClient) TruncateTable(tableName string) (resp *MCDocumentMainR
 		return nil, errors.New("readonly mode active")
 	}
 
-	return m.RunCli([]byte(fmt.Sprintf("TRUNCATE TABLE %s with reconfigure", tableName)))
+	return m.RunCli([]byte(fmt.Sprintf("TRUNCATE TABLE %s with reconfigure", m.clusterTable(tableName))))
 }
 
-// Queries and kill switch - stupid response return text but content type json?
+// Queries and kill switch - stupid response return text but content type json? Typed: GetQueries
 func (m *ManticoreClient) ShowQueries() (resp *interface{}, err error) {
-	if m.IsReadOnly() {
-		return nil, errors.New("readonly mode active")
-	}
-
 	return m.RunCliRaw([]byte("SHOW QUERIES"))
 }
 
 func (m *ManticoreClient) KillQuery(id int) (resp *interface{}, err error) {
+	return m.KillQueryContext(context.Background(), id)
+}
+func (m *ManticoreClient) KillQueryContext(ctx context.Context, id int) (resp *interface{}, err error) {
 	if m.IsReadOnly() {
 		return nil, errors.New("readonly mode active")
 	}
 
-	return m.RunCliRaw([]byte(fmt.Sprintf("KILL %d", id)))
+	return m.RunCliRawContext(ctx, []byte(fmt.Sprintf("KILL %d", id)))
 }
 
 // FLUSH TABLE forcefully flushes RT table RAM chunk contents to disk.
@@ -325,11 +353,8 @@ func (m *ManticoreClient) ExplainQuery(tableName string, query string) (resp *in
 	return m.RunCliRaw([]byte(fmt.Sprintf("EXPLAIN QUERY %s '%s'", tableName, query)))
 }
 
+// Typed: GetServerStatus
 func (m *ManticoreClient) ShowStatus(like string) (resp *MCDocumentMainResponse, err error) {
-	if m.IsReadOnly() {
-		return nil, errors.New("readonly mode active")
-	}
-
 	if like == "" {
 		// show all
 		return m.RunCli([]byte("SHOW STATUS"))
@@ -351,6 +376,9 @@ Endpoint: POST /bulk "Content-Type: application/x-ndjson" JSON
 - you might want to increase max_packet_size value to allow bigger batches
 */
 func (m *ManticoreClient) BulkInsert(items ...MCDocumentUpsertRequest) (resp *MCDocumentBulkResponse, err error) {
+	return m.BulkInsertContext(context.Background(), items...)
+}
+func (m *ManticoreClient) BulkInsertContext(ctx context.Context, items ...MCDocumentUpsertRequest) (resp *MCDocumentBulkResponse, err error) {
 
 	payload := []MCDocumentBulkUpsertRequest{}
 	for _, item := range items {
@@ -359,7 +387,7 @@ func (m *ManticoreClient) BulkInsert(items ...MCDocumentUpsertRequest) (resp *MC
 		})
 	}
 
-	return m.bulkUpsert(MCApiRouteInsert, payload...)
+	return m.bulkUpsert(ctx, MCApiRouteInsert, payload...)
 }
 
 /*
@@ -385,7 +413,7 @@ func (m *ManticoreClient) BulkUpdate(items ...MCDocumentUpsertRequest) (resp *MC
 		})
 	}
 
-	return m.bulkUpsert(MCApiRouteUpdate, payload...)
+	return m.bulkUpsert(context.Background(), MCApiRouteUpdate, payload...)
 }
 
 /*
@@ -412,7 +440,7 @@ func (m *ManticoreClient) BulkReplace(items ...MCDocumentUpsertRequest) (resp *M
 		})
 	}
 
-	return m.bulkUpsert(MCApiRouteReplace, payload...)
+	return m.bulkUpsert(context.Background(), MCApiRouteReplace, payload...)
 }
 
 // Alias insert,replace and delete method
@@ -422,6 +450,7 @@ func (m *ManticoreClient) upsert(action string, v MCDocumentUpsertRequest) (resp
 	}
 
 	// payload
+	v = m.withCluster(v)
 	payload, _ := v.MarshalBinary()
 
 	// Request
@@ -460,7 +489,7 @@ func (m *ManticoreClient) upsert(action string, v MCDocumentUpsertRequest) (resp
 }
 
 // Alias insert,replace and delete bulk method
-func (m *ManticoreClient) bulkUpsert(action string, v ...MCDocumentBulkUpsertRequest) (resp *MCDocumentBulkResponse, err error) {
+func (m *ManticoreClient) bulkUpsert(ctx context.Context, action string, v ...MCDocumentBulkUpsertRequest) (resp *MCDocumentBulkResponse, err error) {
 	if m.IsReadOnly() {
 		return nil, errors.New("readonly mode active")
 	}
@@ -469,6 +498,10 @@ func (m *ManticoreClient) bulkUpsert(action string, v ...MCDocumentBulkUpsertReq
 	payload := new(bytes.Buffer)
 	enc := json.NewEncoder(payload)
 	for _, item := range v {
+		item.Insert = m.withCluster(item.Insert)
+		item.Replace = m.withCluster(item.Replace)
+		item.Update = m.withCluster(item.Update)
+
 		err := enc.Encode(item)
 		if err != nil {
 			return nil, err
@@ -476,7 +509,7 @@ func (m *ManticoreClient) bulkUpsert(action string, v ...MCDocumentBulkUpsertReq
 	}
 
 	// Request
-	code, body, err := m.client.PostNDJSON(m.generateUrl([]string{MCApiRouteBulk}), payload.Bytes())
+	code, body, err := m.client.PostNDJSONContext(ctx, m.generateUrl([]string{MCApiRouteBulk}), payload.Bytes())
 	if err != nil {
 		return nil, err
 	}
@@ -522,6 +555,9 @@ func (m *ManticoreClient) Delete(v MCDocumentDeleteRequest) (resp *MCDocumentRes
 	}
 
 	// payload
+	if v.Cluster == "" {
+		v.Cluster = m.ClusterOf(v.Index)
+	}
 	payload, _ := v.MarshalBinary()
 
 	// Request
@@ -598,12 +634,25 @@ Escape Characters: !    "    $    '    (    )    -    /    <    @    \    ^    |
 All full-text match clauses can be combined with must, must_not and should operators of an HTTP bool query.
 */
 func (m *ManticoreClient) Search(builder *McSearchQueryBuilder) (resp *McSearchResponse, err error) {
+	return m.SearchContext(context.Background(), builder)
+}
+func (m *ManticoreClient) SearchContext(ctx context.Context, builder *McSearchQueryBuilder) (resp *McSearchResponse, err error) {
+	// tag cancellable searches
+	tag := ""
+	if m.killOnCancel && ctx.Done() != nil {
+		builder, tag = tagSearch(builder)
+	}
+
 	// payload
 	payload, _ := builder.MarshalBinary()
 
 	// Request
-	code, body, err := m.client.PostJSON(m.generateUrl([]string{MCApiRouteSearch}), payload)
+	code, body, err := m.client.PostJSONContext(ctx, m.generateUrl([]string{MCApiRouteSearch}), payload)
 	if err != nil {
+		if tag != "" && ctx.Err() != nil {
+			go m.killTaggedQuery(tag)
+		}
+
 		return nil, err
 	}
 
@@ -619,32 +668,6 @@ func (m *ManticoreClient) Search(builder *McSearchQueryBuilder) (resp *McSearchR
 	return resp, nil
 }
 
-/*
-Endpoint: PUT /json/pq/{pq_table_name}/doc/{?id}?refresh=1 JSON
-*/
-func (m *ManticoreClient) InsertPq(v interface{}, profile bool) error {
-	return errors.New("not implemented yet")
-}
-func (m *ManticoreClient) ReplacePq(v interface{}, profile bool) error {
-	return errors.New("not implemented yet")
-}
-func (m *ManticoreClient) UpsertPq(v interface{}, profile bool) error {
-	return errors.New("not implemented yet")
-}
-func (m *ManticoreClient) DeletePq(v MCDocumentDeleteRequest) (resp *MCDocumentResponse, err error) {
-	return m.Delete(v)
-}
-
-/*
-Endpoint: POST /json/pq/{pq_table_name}/search JSON
-*/
-func (m *ManticoreClient) SearchPq(builder McPercolateQueryBuilder, profile bool) error {
-	return errors.New("not implemented yet")
-}
-
-// TODO: Autocomplete
-// TODO: Suggestion
-
 /*
 Backup
 
@@ -724,6 +747,3 @@ func (m *ManticoreClient) Restore(tableName, path string) error {
 
 // TODO: https://manual.manticoresearch.com/Updating_table_schema_and_settings
 // TODO: https://manual.manticoresearch.com/Data_creation_and_modification/Adding_data_from_external_storages/Adding_data_to_tables/Attaching_a_plain_table_to_RT_table#Attaching-table---general-syntax
-
-// TODO:
-type McPercolateQueryBuilder struct{}
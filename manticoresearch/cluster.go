@@ -0,0 +1,318 @@
+package manticoresearch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+/*
+Replication Clusters
+Via: https://manual.manticoresearch.com/Creating_a_cluster/Setting_up_replication/Setting_up_replication
+
+Replication needs a "replication" listener on every node (listen = $ip:9315-9325:replication):
+-> CREATE CLUSTER posts '/var/lib/manticore/posts' as path
+-> JOIN CLUSTER posts '10.0.0.1:9312,10.0.0.2:9312' as nodes   (on other nodes)
+-> ALTER CLUSTER posts ADD products
+-> ALTER CLUSTER posts DROP products
+-> DELETE CLUSTER posts
+-> SET CLUSTER posts GLOBAL 'pc.bootstrap' = 1   (recover primary component after a split)
+
+Writes to a replicated table must name the cluster: "cluster" field of JSON requests, cluster:table in SQL.
+Tables added with AlterClusterAddTable, LoadClusterTables or RegisterMCClusterTables are prefixed automatically.
+*/
+const (
+	ClusterStatusPrimary      = "primary"
+	ClusterStatusNonPrimary   = "non-primary"
+	ClusterStatusDisconnected = "disconnected"
+
+	ClusterNodeStateSynced = "synced"
+)
+
+// table -> cluster registry, used to route writes
+type mcClusterRegistry struct {
+	mu     sync.RWMutex
+	tables map[string]string
+}
+
+type McClusterOptions struct {
+	// Data directory of cluster. Default: data_dir of server
+	Path string
+
+	// Nodes: host:port of API listeners (9312)
+	Nodes []string
+}
+
+type ClusterStatus struct {
+	Name              string
+	StateUUID         string
+	ConfID            int64
+	Status            string // primary, non-primary, disconnected
+	Size              int64  // nodes in cluster
+	LocalIndex        int64
+	NodeState         string // synced, donor, joining, joined, closed, destroyed
+	LocalStateComment string
+	NodesSet          []string
+	NodesView         []string
+	IndexesCount      int64
+	Indexes           []string
+
+	// All cluster counters without cluster_<name>_ prefix
+	Counters map[string]string
+}
+
+// Primary component and local node in sync
+func (s ClusterStatus) Healthy() bool {
+	return s.Status == ClusterStatusPrimary && s.NodeState == ClusterNodeStateSynced
+}
+
+// Writes to tables are routed to cluster
+func RegisterMCClusterTables(cluster string, tableNames ...string) MCOption {
+	return func(m *ManticoreClient) {
+		m.registerClusterTables(cluster, tableNames...)
+	}
+}
+
+func (m *ManticoreClient) CreateCluster(ctx context.Context, name string, opt McClusterOptions) (resp *MCDocumentMainResponse, err error) {
+	return m.clusterCommand(ctx, name, fmt.Sprintf("CREATE CLUSTER %s", name), opt.args()...)
+}
+
+func (m *ManticoreClient) JoinCluster(ctx context.Context, name string, opt McClusterOptions) (resp *MCDocumentMainResponse, err error) {
+	if len(opt.Nodes) == 0 {
+		return nil, errors.New("cluster nodes required")
+	}
+
+	resp, err = m.clusterCommand(ctx, name, fmt.Sprintf("JOIN CLUSTER %s", name), opt.args()...)
+	if err != nil {
+		return nil, err
+	}
+
+	// tables of joined cluster
+	return resp, m.LoadClusterTables(ctx, name)
+}
+
+func (m *ManticoreClient) AlterClusterAddTable(ctx context.Context, name string, tableNames ...string) error {
+	for _, tableName := range tableNames {
+		if !sqlIdentifier.MatchString(tableName) {
+			return fmt.Errorf("invalid table name: %q", tableName)
+		}
+
+		if _, err := m.clusterCommand(ctx, name, fmt.Sprintf("ALTER CLUSTER %s ADD %s", name, tableName)); err != nil {
+			return err
+		}
+
+		m.registerClusterTables(name, tableName)
+	}
+
+	return nil
+}
+
+func (m *ManticoreClient) AlterClusterDropTable(ctx context.Context, name string, tableNames ...string) error {
+	for _, tableName := range tableNames {
+		if !sqlIdentifier.MatchString(tableName) {
+			return fmt.Errorf("invalid table name: %q", tableName)
+		}
+
+		if _, err := m.clusterCommand(ctx, name, fmt.Sprintf("ALTER CLUSTER %s DROP %s", name, tableName)); err != nil {
+			return err
+		}
+
+		m.unregisterClusterTables(name, tableName)
+	}
+
+	return nil
+}
+
+// Removes cluster from all nodes, tables are kept as local tables
+func (m *ManticoreClient) DeleteCluster(ctx context.Context, name string) (resp *MCDocumentMainResponse, err error) {
+	resp, err = m.clusterCommand(ctx, name, fmt.Sprintf("DELETE CLUSTER %s", name))
+	if err != nil {
+		return nil, err
+	}
+
+	m.unregisterClusterTables(name)
+
+	return resp, nil
+}
+
+// Bootstrap primary component on this node (after all nodes went non-primary)
+func (m *ManticoreClient) SetClusterPrimary(ctx context.Context, name string) (resp *MCDocumentMainResponse, err error) {
+	return m.clusterCommand(ctx, name, fmt.Sprintf("SET CLUSTER %s GLOBAL 'pc.bootstrap' = 1", name))
+}
+
+// Cluster counters from SHOW STATUS LIKE 'cluster_<name>_%'
+func (m *ManticoreClient) ShowClusterStatus(ctx context.Context, name string) (*ClusterStatus, error) {
+	if !sqlIdentifier.MatchString(name) {
+		return nil, fmt.Errorf("invalid cluster name: %q", name)
+	}
+
+	prefix := fmt.Sprintf("cluster_%s_", name)
+	resp, err := m.RunCliContext(ctx, []byte(fmt.Sprintf("SHOW STATUS LIKE %s", sqlQuote(prefix+"%"))))
+	if err != nil {
+		return nil, err
+	}
+
+	status := &ClusterStatus{
+		Name:     name,
+		Counters: map[string]string{},
+	}
+
+	for _, row := range resp.Rows() {
+		key := rowString(row, "Counter")
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+
+		status.Counters[strings.TrimPrefix(key, prefix)] = rowString(row, "Value")
+	}
+
+	if len(status.Counters) == 0 {
+		return nil, fmt.Errorf("cluster %s not found", name)
+	}
+
+	c := status.Counters
+	status.StateUUID = c["state_uuid"]
+	status.ConfID, _ = strconv.ParseInt(c["conf_id"], 10, 64)
+	status.Status = c["status"]
+	status.Size, _ = strconv.ParseInt(c["size"], 10, 64)
+	status.LocalIndex, _ = strconv.ParseInt(c["local_index"], 10, 64)
+	status.NodeState = c["node_state"]
+	status.LocalStateComment = c["local_state_comment"]
+	status.NodesSet = splitList(c["nodes_set"])
+	status.NodesView = splitList(c["nodes_view"])
+	status.IndexesCount, _ = strconv.ParseInt(c["indexes_count"], 10, 64)
+	status.Indexes = splitList(c["indexes"])
+
+	return status, nil
+}
+
+// Register tables of cluster for write routing
+func (m *ManticoreClient) LoadClusterTables(ctx context.Context, name string) error {
+	status, err := m.ShowClusterStatus(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	m.unregisterClusterTables(name)
+	m.registerClusterTables(name, status.Indexes...)
+
+	return nil
+}
+
+// Cluster of table, empty if table is not replicated
+func (m *ManticoreClient) ClusterOf(tableName string) string {
+	if m.clusters == nil {
+		return ""
+	}
+
+	m.clusters.mu.RLock()
+	defer m.clusters.mu.RUnlock()
+
+	return m.clusters.tables[tableName]
+}
+
+// Table name for SQL writes: cluster:table
+func (m *ManticoreClient) clusterTable(tableName string) string {
+	if cluster := m.ClusterOf(tableName); cluster != "" {
+		return fmt.Sprintf("%s:%s", cluster, tableName)
+	}
+
+	return tableName
+}
+
+// JSON writes: cluster field
+func (m *ManticoreClient) withCluster(v MCDocumentUpsertRequest) MCDocumentUpsertRequest {
+	if v.Cluster == "" && v.Index != "" {
+		v.Cluster = m.ClusterOf(v.Index)
+	}
+
+	return v
+}
+
+func (m *ManticoreClient) registerClusterTables(cluster string, tableNames ...string) {
+	if m.clusters == nil {
+		m.clusters = &mcClusterRegistry{tables: map[string]string{}}
+	}
+
+	m.clusters.mu.Lock()
+	defer m.clusters.mu.Unlock()
+
+	for _, tableName := range tableNames {
+		m.clusters.tables[tableName] = cluster
+	}
+}
+
+// Unregister given tables of cluster, all tables if none given
+func (m *ManticoreClient) unregisterClusterTables(cluster string, tableNames ...string) {
+	if m.clusters == nil {
+		return
+	}
+
+	m.clusters.mu.Lock()
+	defer m.clusters.mu.Unlock()
+
+	for tableName, c := range m.clusters.tables {
+		if c != cluster {
+			continue
+		}
+
+		if len(tableNames) == 0 || contains(tableNames, tableName) {
+			delete(m.clusters.tables, tableName)
+		}
+	}
+}
+
+func (m *ManticoreClient) clusterCommand(ctx context.Context, name string, cmd string, args ...string) (resp *MCDocumentMainResponse, err error) {
+	if m.IsReadOnly() {
+		return nil, errors.New("readonly mode active")
+	}
+
+	if !sqlIdentifier.MatchString(name) {
+		return nil, fmt.Errorf("invalid cluster name: %q", name)
+	}
+
+	if len(args) > 0 {
+		cmd = fmt.Sprintf("%s %s", cmd, strings.Join(args, ", "))
+	}
+
+	return m.RunCliContext(ctx, []byte(cmd))
+}
+
+// SQL option list: '/var/lib/manticore/posts' as path, '10.0.0.1:9312,10.0.0.2:9312' as nodes
+func (opt McClusterOptions) args() []string {
+	args := []string{}
+	if opt.Path != "" {
+		args = append(args, fmt.Sprintf("%s as path", sqlQuote(opt.Path)))
+	}
+
+	if len(opt.Nodes) > 0 {
+		args = append(args, fmt.Sprintf("%s as nodes", sqlQuote(strings.Join(opt.Nodes, ","))))
+	}
+
+	return args
+}
+
+// Comma or semicolon separated list
+func splitList(s string) []string {
+	items := []string{}
+	for _, item := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ';' }) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+func contains(items []string, s string) bool {
+	for _, item := range items {
+		if item == s {
+			return true
+		}
+	}
+
+	return false
+}
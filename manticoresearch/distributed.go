@@ -0,0 +1,338 @@
+package manticoresearch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/*
+Distributed Tables
+Via: https://manual.manticoresearch.com/Creating_a_table/Creating_a_distributed_table/Creating_a_distributed_table
+
+A distributed table has no data, it sends the query to local tables and remote agents and merges the results:
+-> CREATE TABLE products type='distributed' local='products_1' local='products_2' agent='10.0.0.1:9312:products_3|10.0.0.2:9312:products_3' ha_strategy='roundrobin' agent_connect_timeout='1000'
+
+- local: table on the same server
+- agent: remote table, mirrors separated by "|". Only one mirror of each agent is queried, chosen by ha_strategy
+- agent_persistent: agent with persistent connections (needs max_persistent_connections in searchd)
+- agent_blackhole: query is sent but the response is ignored (e.g. for testing a new node)
+
+Distributed tables can not be altered: AlterDistributedTable drops and recreates the definition.
+This is not atomic, queries sent between DROP and CREATE fail. If CREATE fails, the previous definition is restored.
+Per-query agent settings: McOptions.AddAgentRetryCount, AddMirrorRetryCount, AddAgentQueryTimeout
+*/
+const distributedRestoreTimeout = 30 * time.Second
+
+const (
+	HaStrategyRandom     = "random"
+	HaStrategyRoundRobin = "roundrobin"
+	HaStrategyNoDeads    = "nodeads"
+	HaStrategyNoErrors   = "noerrors"
+)
+
+// Remote table with mirrors: host:port:table
+type DistributedAgent struct {
+	Mirrors []string
+
+	Persistent bool
+	Blackhole  bool
+}
+
+type DistributedTable struct {
+	Name string
+
+	Locals []string
+	Agents []DistributedAgent
+
+	HaStrategy          string
+	AgentConnectTimeout int // milliseconds
+	AgentQueryTimeout   int // milliseconds
+	AgentRetryCount     int
+	MirrorRetryCount    int
+}
+
+func NewDistributedAgent(mirrors ...string) DistributedAgent {
+	return DistributedAgent{
+		Mirrors: mirrors,
+	}
+}
+
+// Mirror of the same remote table: 10.0.0.1:9312:products
+func (a DistributedAgent) AddMirror(host string, port int, tableName string) DistributedAgent {
+	a.Mirrors = append(a.Mirrors, fmt.Sprintf("%s:%d:%s", host, port, tableName))
+
+	return a
+}
+
+func (a DistributedAgent) AddPersistent(enabled bool) DistributedAgent {
+	a.Persistent = enabled
+
+	return a
+}
+
+func (a DistributedAgent) AddBlackhole(enabled bool) DistributedAgent {
+	a.Blackhole = enabled
+
+	return a
+}
+
+// Agent definition: 10.0.0.1:9312:products|10.0.0.2:9312:products
+func (a DistributedAgent) String() string {
+	return strings.Join(a.Mirrors, "|")
+}
+
+func NewDistributedTable(name string) *DistributedTable {
+	return &DistributedTable{
+		Name: name,
+	}
+}
+
+func (d *DistributedTable) AddLocal(tableNames ...string) *DistributedTable {
+	d.Locals = append(d.Locals, tableNames...)
+
+	return d
+}
+
+func (d *DistributedTable) AddAgent(agent DistributedAgent) *DistributedTable {
+	d.Agents = append(d.Agents, agent)
+
+	return d
+}
+
+func (d *DistributedTable) SetHaStrategy(strategy string) *DistributedTable {
+	d.HaStrategy = strategy
+
+	return d
+}
+
+func (d *DistributedTable) SetAgentConnectTimeout(ms int) *DistributedTable {
+	d.AgentConnectTimeout = ms
+
+	return d
+}
+
+func (d *DistributedTable) SetAgentQueryTimeout(ms int) *DistributedTable {
+	d.AgentQueryTimeout = ms
+
+	return d
+}
+
+func (d *DistributedTable) SetAgentRetryCount(retry int) *DistributedTable {
+	d.AgentRetryCount = retry
+
+	return d
+}
+
+func (d *DistributedTable) SetMirrorRetryCount(retry int) *DistributedTable {
+	d.MirrorRetryCount = retry
+
+	return d
+}
+
+// Local and remote members: products_1, 10.0.0.1:9312:products_3|10.0.0.2:9312:products_3
+func (d DistributedTable) Members() []string {
+	members := append([]string{}, d.Locals...)
+	for _, agent := range d.Agents {
+		members = append(members, agent.String())
+	}
+
+	return members
+}
+
+// CREATE TABLE statement
+func (d DistributedTable) CreateTableSQL(ifNotExists bool) (string, error) {
+	if !sqlIdentifier.MatchString(d.Name) {
+		return "", fmt.Errorf("invalid table name: %q", d.Name)
+	}
+
+	if len(d.Locals) == 0 && len(d.Agents) == 0 {
+		return "", fmt.Errorf("distributed table %s has no members", d.Name)
+	}
+
+	cmd := []string{"CREATE TABLE"}
+	if ifNotExists {
+		cmd = append(cmd, "IF NOT EXISTS")
+	}
+	cmd = append(cmd, d.Name, fmt.Sprintf("type=%s", sqlQuote(TableTypeDistributed)))
+
+	for _, local := range d.Locals {
+		if !sqlIdentifier.MatchString(local) {
+			return "", fmt.Errorf("invalid local table name: %q", local)
+		}
+
+		cmd = append(cmd, fmt.Sprintf("local=%s", sqlQuote(local)))
+	}
+
+	for _, agent := range d.Agents {
+		if len(agent.Mirrors) == 0 {
+			return "", fmt.Errorf("agent of distributed table %s has no mirrors", d.Name)
+		}
+
+		if agent.Persistent && agent.Blackhole {
+			return "", fmt.Errorf("agent %s of distributed table %s can not be both persistent and blackhole", agent.String(), d.Name)
+		}
+
+		key := "agent"
+		if agent.Persistent {
+			key = "agent_persistent"
+		} else if agent.Blackhole {
+			key = "agent_blackhole"
+		}
+
+		cmd = append(cmd, fmt.Sprintf("%s=%s", key, sqlQuote(agent.String())))
+	}
+
+	if d.HaStrategy != "" {
+		cmd = append(cmd, fmt.Sprintf("ha_strategy=%s", sqlQuote(d.HaStrategy)))
+	}
+
+	for _, opt := range []struct {
+		name  string
+		value int
+	}{
+		{"agent_connect_timeout", d.AgentConnectTimeout},
+		{"agent_query_timeout", d.AgentQueryTimeout},
+		{"agent_retry_count", d.AgentRetryCount},
+		{"mirror_retry_count", d.MirrorRetryCount},
+	} {
+		if opt.value > 0 {
+			cmd = append(cmd, fmt.Sprintf("%s=%s", opt.name, sqlQuote(strconv.Itoa(opt.value))))
+		}
+	}
+
+	return strings.Join(cmd, " "), nil
+}
+
+func (m *ManticoreClient) CreateDistributedTable(ctx context.Context, d DistributedTable, ifNotExists bool) (resp *MCDocumentMainResponse, err error) {
+	if m.IsReadOnly() {
+		return nil, errors.New("readonly mode active")
+	}
+
+	cmd, err := d.CreateTableSQL(ifNotExists)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.RunCliContext(ctx, []byte(cmd))
+}
+
+// Replace definition of distributed table (DROP + CREATE), create if missing
+func (m *ManticoreClient) AlterDistributedTable(ctx context.Context, d DistributedTable) (resp *MCDocumentMainResponse, err error) {
+	if m.IsReadOnly() {
+		return nil, errors.New("readonly mode active")
+	}
+
+	cmd, err := d.CreateTableSQL(false)
+	if err != nil {
+		return nil, err
+	}
+
+	live, err := m.liveTables(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	t, ok := live[d.Name]
+	if !ok {
+		return m.RunCliContext(ctx, []byte(cmd))
+	}
+
+	// never drop a table with data
+	if t != TableTypeDistributed {
+		return nil, fmt.Errorf("table %s is a %s table, not distributed", d.Name, t)
+	}
+
+	// previous definition, restored if CREATE fails
+	previous, err := m.GetDistributedTable(ctx, d.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	restore, err := previous.CreateTableSQL(true)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := m.RunCliContext(ctx, []byte(fmt.Sprintf("DROP TABLE %s", d.Name))); err != nil {
+		return nil, err
+	}
+
+	resp, err = m.RunCliContext(ctx, []byte(cmd))
+	if err == nil {
+		return resp, nil
+	}
+
+	// ctx may be already cancelled
+	restoreCtx, cancel := context.WithTimeout(context.Background(), distributedRestoreTimeout)
+	defer cancel()
+
+	if _, rerr := m.RunCliContext(restoreCtx, []byte(restore)); rerr != nil {
+		return nil, fmt.Errorf("create distributed table %s: %w (restore of previous definition failed: %v)", d.Name, err, rerr)
+	}
+
+	return nil, fmt.Errorf("create distributed table %s: %w (previous definition restored)", d.Name, err)
+}
+
+// Definition and members of distributed table from SHOW CREATE TABLE
+func (m *ManticoreClient) GetDistributedTable(ctx context.Context, tableName string) (*DistributedTable, error) {
+	if !sqlIdentifier.MatchString(tableName) {
+		return nil, fmt.Errorf("invalid table name: %q", tableName)
+	}
+
+	resp, err := m.RunCliContext(ctx, []byte(fmt.Sprintf("SHOW CREATE TABLE %s", tableName)))
+	if err != nil {
+		return nil, err
+	}
+
+	rows := resp.Rows()
+	if len(rows) == 0 {
+		return nil, errors.New("empty SHOW CREATE TABLE response")
+	}
+
+	return parseDistributedTable(tableName, rowString(rows[0], "Create Table"))
+}
+
+func parseDistributedTable(tableName string, stmt string) (*DistributedTable, error) {
+	d := NewDistributedTable(tableName)
+	unescape := strings.NewReplacer(`\'`, `'`, `\\`, `\`)
+
+	isDistributed := false
+	for _, match := range sqlSettingPattern.FindAllStringSubmatch(stmt, -1) {
+		value := unescape.Replace(match[2])
+
+		// settings can be repeated (local, agent), parseSettings keeps only the last one
+		switch key := strings.ToLower(match[1]); key {
+		case TableSettingType:
+			isDistributed = value == TableTypeDistributed
+		case "local":
+			for _, local := range strings.Split(value, ",") {
+				d.AddLocal(strings.TrimSpace(local))
+			}
+		case "agent", "agent_persistent", "agent_blackhole":
+			agent := NewDistributedAgent(strings.Split(value, "|")...)
+			agent.Persistent = key == "agent_persistent"
+			agent.Blackhole = key == "agent_blackhole"
+			d.AddAgent(agent)
+		case "ha_strategy":
+			d.HaStrategy = value
+		case "agent_connect_timeout":
+			d.AgentConnectTimeout, _ = strconv.Atoi(value)
+		case "agent_query_timeout":
+			d.AgentQueryTimeout, _ = strconv.Atoi(value)
+		case "agent_retry_count":
+			d.AgentRetryCount, _ = strconv.Atoi(value)
+		case "mirror_retry_count":
+			d.MirrorRetryCount, _ = strconv.Atoi(value)
+		}
+	}
+
+	if !isDistributed {
+		return nil, fmt.Errorf("table %s is not distributed", tableName)
+	}
+
+	return d, nil
+}
@@ -0,0 +1,146 @@
+package manticoresearch
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDistributedTableCreateTableSQL(t *testing.T) {
+	tests := []struct {
+		name        string
+		table       *DistributedTable
+		ifNotExists bool
+		want        string
+		wantErr     bool
+	}{
+		{
+			name:  "locals",
+			table: NewDistributedTable("products").AddLocal("products_1", "products_2"),
+			want:  "CREATE TABLE products type='distributed' local='products_1' local='products_2'",
+		},
+		{
+			name: "agents and options",
+			table: NewDistributedTable("products").
+				AddAgent(NewDistributedAgent().AddMirror("10.0.0.1", 9312, "products_3").AddMirror("10.0.0.2", 9312, "products_3")).
+				AddAgent(NewDistributedAgent("10.0.0.3:9312:products_4").AddPersistent(true)).
+				AddAgent(NewDistributedAgent("10.0.0.4:9312:products_5").AddBlackhole(true)).
+				SetHaStrategy(HaStrategyRoundRobin).
+				SetAgentConnectTimeout(1000).
+				SetMirrorRetryCount(2),
+			ifNotExists: true,
+			want: "CREATE TABLE IF NOT EXISTS products type='distributed' " +
+				"agent='10.0.0.1:9312:products_3|10.0.0.2:9312:products_3' " +
+				"agent_persistent='10.0.0.3:9312:products_4' " +
+				"agent_blackhole='10.0.0.4:9312:products_5' " +
+				"ha_strategy='roundrobin' agent_connect_timeout='1000' mirror_retry_count='2'",
+		},
+		{
+			name:    "no members",
+			table:   NewDistributedTable("products"),
+			wantErr: true,
+		},
+		{
+			name:    "invalid local",
+			table:   NewDistributedTable("products").AddLocal("products 1"),
+			wantErr: true,
+		},
+		{
+			name:    "agent without mirrors",
+			table:   NewDistributedTable("products").AddAgent(NewDistributedAgent()),
+			wantErr: true,
+		},
+		{
+			name:    "persistent and blackhole agent",
+			table:   NewDistributedTable("products").AddAgent(NewDistributedAgent("10.0.0.1:9312:products").AddPersistent(true).AddBlackhole(true)),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.table.CreateTableSQL(tt.ifNotExists)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CreateTableSQL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("CreateTableSQL() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDistributedTable(t *testing.T) {
+	tests := []struct {
+		name    string
+		stmt    string
+		want    *DistributedTable
+		wantErr bool
+	}{
+		{
+			name: "repeated locals and agents",
+			stmt: "CREATE TABLE products type='distributed' local='products_1' local='products_2,products_3' " +
+				"agent='10.0.0.1:9312:p|10.0.0.2:9312:p' agent_persistent='10.0.0.3:9312:p' agent_blackhole='10.0.0.4:9312:p' " +
+				"ha_strategy='nodeads' agent_connect_timeout='500' agent_query_timeout='3000' agent_retry_count='1' mirror_retry_count='2'",
+			want: &DistributedTable{
+				Name:   "products",
+				Locals: []string{"products_1", "products_2", "products_3"},
+				Agents: []DistributedAgent{
+					{Mirrors: []string{"10.0.0.1:9312:p", "10.0.0.2:9312:p"}},
+					{Mirrors: []string{"10.0.0.3:9312:p"}, Persistent: true},
+					{Mirrors: []string{"10.0.0.4:9312:p"}, Blackhole: true},
+				},
+				HaStrategy:          HaStrategyNoDeads,
+				AgentConnectTimeout: 500,
+				AgentQueryTimeout:   3000,
+				AgentRetryCount:     1,
+				MirrorRetryCount:    2,
+			},
+		},
+		{
+			name: "upper case keys",
+			stmt: "CREATE TABLE products TYPE='distributed' LOCAL='products_1'",
+			want: &DistributedTable{Name: "products", Locals: []string{"products_1"}},
+		},
+		{
+			name:    "rt table",
+			stmt:    "CREATE TABLE products (title text) morphology='stem_en'",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseDistributedTable("products", tt.stmt)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseDistributedTable() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseDistributedTable() =\n%#v\nwant\n%#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDistributedTableRoundTrip(t *testing.T) {
+	table := NewDistributedTable("products").
+		AddLocal("products_1").
+		AddAgent(NewDistributedAgent("10.0.0.1:9312:p", "10.0.0.2:9312:p").AddPersistent(true)).
+		SetHaStrategy(HaStrategyRandom).
+		SetAgentQueryTimeout(3000)
+
+	stmt, err := table.CreateTableSQL(false)
+	if err != nil {
+		t.Fatalf("CreateTableSQL() error = %v", err)
+	}
+
+	got, err := parseDistributedTable("products", stmt)
+	if err != nil {
+		t.Fatalf("parseDistributedTable() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(got, table) {
+		t.Errorf("round trip =\n%#v\nwant\n%#v", got, table)
+	}
+}
@@ -0,0 +1,230 @@
+package manticoresearch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+/*
+Health Check
+
+- Ping: server answers on the HTTP listener (GET /)
+- Ready: Ping, SQL probe and per-table checks pass
+- Check: result of all checks, cached for CacheTTL
+
+HealthChecker is an http.Handler, e.g. for kubernetes probes:
+
+	checker := client.NewHealthChecker(HealthCheckOptions{Timeout: 2 * time.Second, CacheTTL: 5 * time.Second, Tables: []string{"products"}})
+	http.Handle("/healthz", checker)
+
+	GET /healthz -> 200 (up, degraded) or 503 (down)
+	{
+		"status": "degraded",
+		"version": "6.3.6 ...",
+		"checks": [
+			{"name": "info", "status": "up", "latency_ms": 1},
+			{"name": "sql", "status": "up", "latency_ms": 1},
+			{"name": "table:products", "status": "degraded", "latency_ms": 2, "error": "41 disk chunks, more than 32: run OPTIMIZE"}
+		],
+		"checked_at": "2024-01-01T10:00:00Z"
+	}
+
+Table health issues (EvaluateTableHealth) degrade the status, missing tables and failing probes bring it down.
+*/
+const (
+	HealthStatusUp       = "up"
+	HealthStatusDegraded = "degraded"
+	HealthStatusDown     = "down"
+)
+
+const DefaultHealthCheckTimeout = 2 * time.Second
+
+type HealthCheckOptions struct {
+	// Timeout of all checks. Default: 2s
+	Timeout time.Duration
+
+	// Reuse last result. Default: 0 (no cache)
+	CacheTTL time.Duration
+
+	// Tables must exist, their health is evaluated with TableHealth thresholds
+	Tables      []string
+	TableHealth TableHealthThresholds
+}
+
+type HealthComponent struct {
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	LatencyMs int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
+
+type HealthResult struct {
+	Status    string            `json:"status"`
+	Version   string            `json:"version,omitempty"`
+	Checks    []HealthComponent `json:"checks"`
+	CheckedAt time.Time         `json:"checked_at"`
+	Cached    bool              `json:"cached,omitempty"`
+}
+
+type HealthChecker struct {
+	client *ManticoreClient
+	opt    HealthCheckOptions
+
+	// guards cached
+	mu     sync.Mutex
+	cached *HealthResult
+}
+
+func (m *ManticoreClient) NewHealthChecker(opt HealthCheckOptions) *HealthChecker {
+	if opt.Timeout <= 0 {
+		opt.Timeout = DefaultHealthCheckTimeout
+	}
+
+	return &HealthChecker{
+		client: m,
+		opt:    opt,
+	}
+}
+
+// Liveness: server answers
+func (h *HealthChecker) Ping(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, h.opt.Timeout)
+	defer cancel()
+
+	_, err := h.client.InfoContext(ctx)
+
+	return err
+}
+
+// Readiness: no check is down
+func (h *HealthChecker) Ready(ctx context.Context) error {
+	result := h.Check(ctx)
+	if result.Status != HealthStatusDown {
+		return nil
+	}
+
+	failed := []string{}
+	for _, c := range result.Checks {
+		if c.Status == HealthStatusDown {
+			failed = append(failed, fmt.Sprintf("%s: %s", c.Name, c.Error))
+		}
+	}
+
+	return errors.New(strings.Join(failed, "; "))
+}
+
+// All checks, cached for CacheTTL
+func (h *HealthChecker) Check(ctx context.Context) HealthResult {
+	// lock only guards the cache, concurrent checks may run when it expires
+	h.mu.Lock()
+	if h.cached != nil && h.opt.CacheTTL > 0 && time.Since(h.cached.CheckedAt) < h.opt.CacheTTL {
+		result := *h.cached
+		result.Cached = true
+		h.mu.Unlock()
+
+		return result
+	}
+	h.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, h.opt.Timeout)
+	defer cancel()
+
+	result := HealthResult{
+		Status:    HealthStatusUp,
+		Checks:    []HealthComponent{},
+		CheckedAt: time.Now(),
+	}
+
+	// info
+	start := time.Now()
+	info, err := h.client.InfoContext(ctx)
+	if err == nil && info != nil {
+		result.Version = info.Version.Number
+	}
+	result.add(newHealthComponent("info", start, err))
+
+	// sql probe: cheapest statement using a worker thread
+	start = time.Now()
+	_, err = h.client.RunCliContext(ctx, []byte("SHOW STATUS LIKE 'uptime'"))
+	result.add(newHealthComponent("sql", start, err))
+
+	for _, tableName := range h.opt.Tables {
+		start = time.Now()
+		status, err := h.client.GetTableStatus(ctx, tableName)
+
+		component := newHealthComponent("table:"+tableName, start, err)
+		if err == nil {
+			if health := EvaluateTableHealth(*status, h.opt.TableHealth); !health.Healthy() {
+				messages := []string{}
+				for _, issue := range health.Issues {
+					messages = append(messages, issue.Message)
+				}
+
+				component.Status = HealthStatusDegraded
+				component.Error = strings.Join(messages, "; ")
+			}
+		}
+
+		result.add(component)
+	}
+
+	// cancelled or timed out checks are not cached, nor older results of concurrent checks
+	if ctx.Err() == nil {
+		h.mu.Lock()
+		if h.cached == nil || result.CheckedAt.After(h.cached.CheckedAt) {
+			h.cached = &result
+		}
+		h.mu.Unlock()
+	}
+
+	return result
+}
+
+// JSON result: 200 when up or degraded, 503 when down
+func (h *HealthChecker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	result := h.Check(r.Context())
+
+	code := http.StatusOK
+	if result.Status == HealthStatusDown {
+		code = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+
+	_ = json.NewEncoder(w).Encode(result)
+}
+
+func newHealthComponent(name string, start time.Time, err error) HealthComponent {
+	component := HealthComponent{
+		Name:      name,
+		Status:    HealthStatusUp,
+		LatencyMs: time.Since(start).Milliseconds(),
+	}
+
+	if err != nil {
+		component.Status = HealthStatusDown
+		component.Error = err.Error()
+	}
+
+	return component
+}
+
+// Worst component status wins
+func (r *HealthResult) add(component HealthComponent) {
+	r.Checks = append(r.Checks, component)
+
+	switch {
+	case component.Status == HealthStatusDown:
+		r.Status = HealthStatusDown
+	case component.Status == HealthStatusDegraded && r.Status == HealthStatusUp:
+		r.Status = HealthStatusDegraded
+	}
+}
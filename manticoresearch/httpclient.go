@@ -2,6 +2,7 @@ package manticoresearch
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -85,47 +86,65 @@ func (a HttpClient) StatusOK(code int) bool {
 
 // get request
 func (a HttpClient) Get(url string) (code int, body []byte, err error) {
-	return a._request(http.MethodGet, url, nil, nil, false)
+	return a.GetContext(context.Background(), url)
+}
+func (a HttpClient) GetContext(ctx context.Context, url string) (code int, body []byte, err error) {
+	return a._request(ctx, http.MethodGet, url, nil, nil, false)
 }
 
 // post request
 func (a HttpClient) Post(url string, payload []byte) (code int, body []byte, err error) {
+	return a.PostContext(context.Background(), url, payload)
+}
+func (a HttpClient) PostContext(ctx context.Context, url string, payload []byte) (code int, body []byte, err error) {
 	headers := map[string]string{
 		"Content-Type": "text/plain",
 	}
-	return a._request(http.MethodPost, url, headers, bytes.NewBuffer(payload), false)
+	return a._request(ctx, http.MethodPost, url, headers, bytes.NewBuffer(payload), false)
 }
 
 // post json request
 func (a *HttpClient) PostJSON(url string, payload []byte) (code int, body []byte, err error) {
+	return a.PostJSONContext(context.Background(), url, payload)
+}
+func (a *HttpClient) PostJSONContext(ctx context.Context, url string, payload []byte) (code int, body []byte, err error) {
 	// headers
 	headers := map[string]string{
 		"Content-Type": "application/json",
 	}
-	return a._request(http.MethodPost, url, headers, bytes.NewBuffer(payload), false)
+	return a._request(ctx, http.MethodPost, url, headers, bytes.NewBuffer(payload), false)
 }
 
 // post ndjson request
 func (a HttpClient) PostNDJSON(url string, payload []byte) (code int, body []byte, err error) {
+	return a.PostNDJSONContext(context.Background(), url, payload)
+}
+func (a HttpClient) PostNDJSONContext(ctx context.Context, url string, payload []byte) (code int, body []byte, err error) {
 	headers := map[string]string{
 		"Content-Type": "application/x-ndjson",
 	}
-	return a._request(http.MethodPost, url, headers, bytes.NewBuffer(payload), false)
+	return a._request(ctx, http.MethodPost, url, headers, bytes.NewBuffer(payload), false)
 }
 
 // put request
 func (a HttpClient) Put(url string, payload []byte) (code int, body []byte, err error) {
-	return a._request(http.MethodPut, url, nil, bytes.NewBuffer(payload), false)
+	return a._request(context.Background(), http.MethodPut, url, nil, bytes.NewBuffer(payload), false)
 }
 
 // put json request
 func (a HttpClient) PutJSON(url string, payload []byte) (code int, body []byte, err error) {
-	return a._request(http.MethodPut, url, nil, bytes.NewBuffer(payload), false)
+	return a.PutJSONContext(context.Background(), url, payload)
+}
+func (a HttpClient) PutJSONContext(ctx context.Context, url string, payload []byte) (code int, body []byte, err error) {
+	headers := map[string]string{
+		"Content-Type": "application/json",
+	}
+	return a._request(ctx, http.MethodPut, url, headers, bytes.NewBuffer(payload), false)
 }
 
 // Private
-func (a *HttpClient) _request(method, url string, headers map[string]string, payload io.Reader, statusOnly bool) (code int, body []byte, err error) {
-	req, err := http.NewRequest(method, url, payload)
+func (a *HttpClient) _request(ctx context.Context, method, url string, headers map[string]string, payload io.Reader, statusOnly bool) (code int, body []byte, err error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, payload)
 	if err != nil {
 		return 0, nil, err
 	}
@@ -0,0 +1,262 @@
+package manticoresearch
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+/*
+Search Iterator (keyset pagination)
+
+offset/limit pagination stops working after max_matches documents (Default: 1000). The iterator never skips rows,
+it reruns the same query with the last sort key of the previous page appended as a range filter:
+
+	"query": {
+		"bool": {
+			"must": [
+				{ <original query> },
+				{
+					"bool": {
+						"should": [
+							{ "range": { "price": { "gt": 500 } } },
+							{ "bool": { "must": [
+								{ "equals": { "price": 500 } },
+								{ "range": { "id": { "gt": 1234 } } }
+							] } }
+						]
+					}
+				}
+			]
+		}
+	},
+	"sort": [ { "price": "asc" }, { "id": "asc" } ]
+
+Ties on the sort attribute are broken on document id, so every document is returned exactly once.
+*/
+var ErrIteratorDone = errors.New("no more hits in iterator")
+
+const DefaultMcSearchIteratorPageSize = 100
+
+// Sort key extractor: returns the value of the sort attribute for a hit
+type McSearchIteratorKeyFunc func(hit *McSearchResponseHitsHits) interface{}
+
+type SearchIterator struct {
+	client  *ManticoreClient
+	builder McSearchQueryBuilder
+
+	// sort attribute and order (empty field: sort by id only)
+	field string
+	order string
+
+	pageSize int64
+	keyFunc  McSearchIteratorKeyFunc
+
+	// last seen sort key
+	lastID    uint64
+	lastValue interface{}
+	started   bool
+
+	hits []McSearchResponseHitsHits
+	done bool
+}
+
+func (m *ManticoreClient) NewSearchIterator(builder *McSearchQueryBuilder, field string, order string, pageSize int64) *SearchIterator {
+	if order != MCSortOrderDESC {
+		order = MCSortOrderASC
+	}
+
+	if pageSize <= 0 {
+		pageSize = DefaultMcSearchIteratorPageSize
+	}
+
+	return &SearchIterator{
+		client:   m,
+		builder:  *builder,
+		field:    field,
+		order:    order,
+		pageSize: pageSize,
+	}
+}
+
+// Custom sort key extractor. Default: lookup field in hit source by json tag
+func (it *SearchIterator) SetKeyFunc(fn McSearchIteratorKeyFunc) *SearchIterator {
+	it.keyFunc = fn
+
+	return it
+}
+
+// Next returns the next hit, or ErrIteratorDone when all hits are consumed.
+func (it *SearchIterator) Next(ctx context.Context) (*McSearchResponseHitsHits, error) {
+	if len(it.hits) == 0 {
+		if it.done {
+			return nil, ErrIteratorDone
+		}
+
+		if err := it.fetch(ctx); err != nil {
+			return nil, err
+		}
+
+		if len(it.hits) == 0 {
+			return nil, ErrIteratorDone
+		}
+	}
+
+	hit := it.hits[0]
+	it.hits = it.hits[1:]
+
+	return &hit, nil
+}
+
+func (it *SearchIterator) fetch(ctx context.Context) error {
+	builder := it.builder
+	builder.Query = it.pageQuery()
+	builder.Sort = it.pageSort()
+	builder.Offset = 0
+	builder.From = 0
+	builder.Size = 0
+	builder.Limit = it.pageSize
+
+	// pages larger than max_matches (Default: 1000) are cut by the server
+	if it.pageSize > builder.MaxMatches {
+		builder.MaxMatches = it.pageSize
+	}
+
+	resp, err := it.client.SearchContext(ctx, &builder)
+	if err != nil {
+		return err
+	}
+
+	if resp.Hits == nil || len(resp.Hits.Hits) == 0 {
+		it.done = true
+		return nil
+	}
+
+	hits := resp.Hits.Hits
+	if int64(len(hits)) < it.pageSize {
+		it.done = true
+	}
+
+	// remember last sort key
+	last := hits[len(hits)-1]
+	id, err := strconv.ParseUint(last.ID_, 10, 64)
+	if err != nil {
+		return err
+	}
+
+	it.lastID = id
+	if it.field != "" {
+		if it.keyFunc != nil {
+			it.lastValue = it.keyFunc(&last)
+		} else {
+			it.lastValue = sourceFieldValue(last.Source, it.field)
+		}
+
+		if it.lastValue == nil {
+			return errors.New("sort attribute not found in hit source: " + it.field)
+		}
+	}
+
+	it.started = true
+	it.hits = hits
+
+	return nil
+}
+
+func (it *SearchIterator) pageSort() []interface{} {
+	sorts := []interface{}{}
+	if it.field != "" {
+		sorts = append(sorts, map[string]string{it.field: it.order})
+	}
+
+	return append(sorts, map[string]string{"id": it.order})
+}
+
+func (it *SearchIterator) pageQuery() *McQueryOptions {
+	if !it.started {
+		return it.builder.Query
+	}
+
+	op := "gt"
+	if it.order == MCSortOrderDESC {
+		op = "lt"
+	}
+
+	idRange := newLeafClause(McQueryMatchFilterVariousRange, "", "id", map[string]interface{}{op: it.lastID})
+
+	var keyset McQueryClause = idRange
+	if it.field != "" {
+		keyset = Bool().Should(
+			newLeafClause(McQueryMatchFilterVariousRange, "", it.field, map[string]interface{}{op: it.lastValue}),
+			Bool().Must(Equals(it.field, it.lastValue), idRange),
+		)
+	}
+
+	query := NewMcQueryOptions()
+	if it.builder.Query != nil {
+		for _, clause := range it.builder.Query.sections() {
+			query.AddBoolClause(McQueryMatchFilterSectionMust, clause)
+		}
+	}
+
+	return query.AddBoolClause(McQueryMatchFilterSectionMust, keyset)
+}
+
+// Split query options into standalone clauses, usable inside bool sections
+func (qb *McQueryOptions) sections() (clauses []McQueryClause) {
+	if qb.Match != nil {
+		clauses = append(clauses, McLeafClause{McQueryMatchFilterVariousMatch: qb.Match})
+	}
+	if qb.MatchPhrase != nil {
+		clauses = append(clauses, McLeafClause{McQueryMatchFilterVariousMatchPhrase: qb.MatchPhrase})
+	}
+	if qb.QueryString != nil {
+		clauses = append(clauses, QueryString(*qb.QueryString))
+	}
+	if qb.MatchAll != nil {
+		clauses = append(clauses, McLeafClause{McQueryMatchFilterVariousMatchAll: qb.MatchAll})
+	}
+	if qb.Equals != nil {
+		for k, v := range *qb.Equals {
+			clauses = append(clauses, Equals(k, v))
+		}
+	}
+	if qb.In != nil {
+		for k, v := range *qb.In {
+			clauses = append(clauses, In(k, v))
+		}
+	}
+	if qb.Range != nil {
+		for k, v := range *qb.Range {
+			clauses = append(clauses, newLeafClause(McQueryMatchFilterVariousRange, "", k, v))
+		}
+	}
+	if qb.GeoDistance != nil {
+		clauses = append(clauses, McLeafClause{McQueryMatchFilterVariousGeoDistance: qb.GeoDistance})
+	}
+	if qb.Bool != nil {
+		clauses = append(clauses, McLeafClause{McQueryMatchFilterBool: qb.Bool})
+	}
+
+	return clauses
+}
+
+// Lookup source attribute by json tag name
+func sourceFieldValue(source interface{}, field string) interface{} {
+	v := reflect.Indirect(reflect.ValueOf(source))
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name == field {
+			return v.Field(i).Interface()
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,155 @@
+package manticoresearch
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// compare as decoded json: map key order does not matter
+func assertJSON(t *testing.T, name string, v interface{}, want string) {
+	t.Helper()
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("%s: marshal error = %v", name, err)
+	}
+
+	var got, expected interface{}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("%s: unmarshal error = %v", name, err)
+	}
+
+	if err := json.Unmarshal([]byte(want), &expected); err != nil {
+		t.Fatalf("%s: invalid expected json: %v", name, err)
+	}
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("%s =\n%s\nwant\n%s", name, data, want)
+	}
+}
+
+func TestSearchIteratorPage(t *testing.T) {
+	client := &ManticoreClient{}
+	query := NewMcQueryOptions().AddEquals("brand_id", 3)
+
+	tests := []struct {
+		name      string
+		field     string
+		order     string
+		lastID    uint64
+		lastValue interface{}
+		started   bool
+		wantQuery string
+		wantSort  string
+	}{
+		{
+			name:      "first page keeps original query",
+			field:     "price",
+			order:     MCSortOrderASC,
+			wantQuery: `{"equals":{"brand_id":3}}`,
+			wantSort:  `[{"price":"asc"},{"id":"asc"}]`,
+		},
+		{
+			name:      "id only asc",
+			order:     MCSortOrderASC,
+			lastID:    1234,
+			started:   true,
+			wantQuery: `{"bool":{"must":[{"equals":{"brand_id":3}},{"range":{"id":{"gt":1234}}}]}}`,
+			wantSort:  `[{"id":"asc"}]`,
+		},
+		{
+			name:      "id only desc",
+			order:     MCSortOrderDESC,
+			lastID:    1234,
+			started:   true,
+			wantQuery: `{"bool":{"must":[{"equals":{"brand_id":3}},{"range":{"id":{"lt":1234}}}]}}`,
+			wantSort:  `[{"id":"desc"}]`,
+		},
+		{
+			name:      "sort field asc with id tie-break",
+			field:     "price",
+			order:     MCSortOrderASC,
+			lastID:    1234,
+			lastValue: 500,
+			started:   true,
+			wantQuery: `{"bool":{"must":[
+				{"equals":{"brand_id":3}},
+				{"bool":{"should":[
+					{"range":{"price":{"gt":500}}},
+					{"bool":{"must":[{"equals":{"price":500}},{"range":{"id":{"gt":1234}}}]}}
+				]}}
+			]}}`,
+			wantSort: `[{"price":"asc"},{"id":"asc"}]`,
+		},
+		{
+			name:      "sort field desc with id tie-break",
+			field:     "price",
+			order:     MCSortOrderDESC,
+			lastID:    1234,
+			lastValue: 500,
+			started:   true,
+			wantQuery: `{"bool":{"must":[
+				{"equals":{"brand_id":3}},
+				{"bool":{"should":[
+					{"range":{"price":{"lt":500}}},
+					{"bool":{"must":[{"equals":{"price":500}},{"range":{"id":{"lt":1234}}}]}}
+				]}}
+			]}}`,
+			wantSort: `[{"price":"desc"},{"id":"desc"}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			it := client.NewSearchIterator(NewMCSearchQueryBuilder("products").SetQuery(query), tt.field, tt.order, 0)
+			it.lastID = tt.lastID
+			it.lastValue = tt.lastValue
+			it.started = tt.started
+
+			assertJSON(t, "pageQuery()", it.pageQuery(), tt.wantQuery)
+			assertJSON(t, "pageSort()", it.pageSort(), tt.wantSort)
+		})
+	}
+}
+
+func TestSearchIteratorMaxMatches(t *testing.T) {
+	tests := []struct {
+		name           string
+		pageSize       int64
+		maxMatches     int64
+		wantMaxMatches int64
+	}{
+		{"page above default max_matches", 5000, 0, 5000},
+		{"builder value kept when larger", 5000, 10000, 10000},
+		{"small page", 100, 0, 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got McSearchQueryBuilder
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				_ = json.Unmarshal(body, &got)
+				_, _ = w.Write([]byte(`{"hits":{"total":0,"hits":[]}}`))
+			}))
+			defer server.Close()
+
+			client := NewManticoreClient(RegisterMCDefaultHttpClient(), RegisterMCApiSettings(server.URL, false))
+			builder := NewMCSearchQueryBuilder("products").SetMaxMatches(tt.maxMatches)
+
+			it := client.NewSearchIterator(builder, "", MCSortOrderASC, tt.pageSize)
+			if _, err := it.Next(context.Background()); err != ErrIteratorDone {
+				t.Fatalf("Next() error = %v, want ErrIteratorDone", err)
+			}
+
+			if got.Limit != tt.pageSize || got.MaxMatches != tt.wantMaxMatches {
+				t.Errorf("limit = %d, max_matches = %d, want %d and %d", got.Limit, got.MaxMatches, tt.pageSize, tt.wantMaxMatches)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package manticoresearch
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+/*
+CALL KEYWORDS
+Via: https://manual.manticoresearch.com/Searching/Autocomplete#CALL-KEYWORDS
+
+Shows how the table tokenizes the text (charset_table, morphology, wordforms etc.), without running a search:
+-> CALL KEYWORDS('running dogs', 'table', 1 as stats, 1 as fold_lemmas)
+
++------+-----------+------------+------+------+
+| qpos | tokenized | normalized | docs | hits |
++------+-----------+------------+------+------+
+| 1    | running   | run        | 10   | 12   |
+| 2    | dogs      | dog        | 3    | 3    |
++------+-----------+------------+------+------+
+*/
+const (
+	McKeywordsSortModeDocs = "docs"
+	McKeywordsSortModeHits = "hits"
+)
+
+type McKeywordsOptions struct {
+	Stats          bool   // return docs and hits statistics
+	FoldLemmas     bool   // fold lemmas of lemmatizer_all morphology into one normalized form
+	FoldBlended    bool   // fold blended words into one token
+	FoldWildcards  *bool  // fold wildcard expansions into one row. Default: 1
+	ExpansionLimit int    // max number of wildcard expansions. Default: expansion_limit setting
+	SortMode       string // order of wildcard expansions: docs or hits
+}
+
+func NewMcKeywordsOptions() McKeywordsOptions {
+	return McKeywordsOptions{}
+}
+
+func (qb McKeywordsOptions) AddStats(enabled bool) McKeywordsOptions {
+	qb.Stats = enabled
+
+	return qb
+}
+
+func (qb McKeywordsOptions) AddFoldLemmas(enabled bool) McKeywordsOptions {
+	qb.FoldLemmas = enabled
+
+	return qb
+}
+
+func (qb McKeywordsOptions) AddFoldBlended(enabled bool) McKeywordsOptions {
+	qb.FoldBlended = enabled
+
+	return qb
+}
+
+func (qb McKeywordsOptions) AddFoldWildcards(enabled bool) McKeywordsOptions {
+	qb.FoldWildcards = &enabled
+
+	return qb
+}
+
+func (qb McKeywordsOptions) AddExpansionLimit(limit int) McKeywordsOptions {
+	qb.ExpansionLimit = limit
+
+	return qb
+}
+
+func (qb McKeywordsOptions) AddSortMode(mode string) McKeywordsOptions {
+	qb.SortMode = mode
+
+	return qb
+}
+
+type McKeyword struct {
+	Qpos       int64  // position in the query
+	Tokenized  string // token after charset_table
+	Normalized string // token after morphology and wordforms
+	Docs       int64  // Stats only
+	Hits       int64  // Stats only
+}
+
+func (m *ManticoreClient) Keywords(ctx context.Context, tableName string, text string, opt McKeywordsOptions) ([]McKeyword, error) {
+	args := []string{sqlQuote(text), sqlQuote(tableName)}
+
+	if opt.Stats {
+		args = append(args, "1 as stats")
+	}
+
+	if opt.FoldLemmas {
+		args = append(args, "1 as fold_lemmas")
+	}
+
+	if opt.FoldBlended {
+		args = append(args, "1 as fold_blended")
+	}
+
+	if opt.FoldWildcards != nil {
+		args = append(args, fmt.Sprintf("%d as fold_wildcards", boolInt(*opt.FoldWildcards)))
+	}
+
+	if opt.ExpansionLimit > 0 {
+		args = append(args, fmt.Sprintf("%d as expansion_limit", opt.ExpansionLimit))
+	}
+
+	if opt.SortMode != "" {
+		args = append(args, fmt.Sprintf("%s as sort_mode", sqlQuote(opt.SortMode)))
+	}
+
+	resp, err := m.RunCliContext(ctx, []byte(fmt.Sprintf("CALL KEYWORDS(%s)", strings.Join(args, ", "))))
+	if err != nil {
+		return nil, err
+	}
+
+	keywords := []McKeyword{}
+	for _, row := range resp.Rows() {
+		keywords = append(keywords, McKeyword{
+			Qpos:       rowInt64(row, "qpos"),
+			Tokenized:  rowString(row, "tokenized"),
+			Normalized: rowString(row, "normalized"),
+			Docs:       rowInt64(row, "docs"),
+			Hits:       rowInt64(row, "hits"),
+		})
+	}
+
+	return keywords, nil
+}
@@ -0,0 +1,353 @@
+package manticoresearch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+/*
+Table Maintenance Scheduler
+
+Runs OPTIMIZE, FLUSH TABLE, FLUSH ATTRIBUTES and ALTER TABLE RECONFIGURE on schedules (see ParseSchedule):
+
+	maintenance, err := client.NewMaintenance(MaintenanceOptions{
+		Tasks: []MaintenanceTask{
+			{Table: "products", Action: MaintenanceOptimize, Schedule: "0 3 * * *", MinDiskChunks: 8},
+			{Table: "products", Action: MaintenanceFlush, Schedule: "@every 1h"},
+			{Action: MaintenanceFlushAttributes, Schedule: "@every 15m"},
+		},
+		OnResult: func(r MaintenanceResult) { ... },
+	})
+	maintenance.Start(ctx)
+	defer maintenance.Stop()
+
+Optimize is skipped unless SHOW TABLE STATUS reports more than MinDiskChunks disk chunks or at least MinRamBytes in RAM chunk.
+Tasks of the same table never overlap: a run is skipped while another task of the table is still running.
+*/
+const (
+	MaintenanceOptimize        = "optimize"
+	MaintenanceFlush           = "flush"
+	MaintenanceFlushAttributes = "flush_attributes"
+	MaintenanceReconfigure     = "reconfigure"
+)
+
+type MaintenanceTask struct {
+	// Empty for flush_attributes
+	Table string
+
+	Action   string
+	Schedule string
+
+	// Optimize thresholds from SHOW TABLE STATUS, zero: always optimize
+	MinDiskChunks int64
+	MinRamBytes   int64
+
+	// Optimize options: target disk chunk count (0: server default) and wait for completion
+	Cutoff int
+	Sync   bool
+}
+
+type MaintenanceResult struct {
+	Task  MaintenanceTask
+	Start time.Time
+	End   time.Time
+
+	Skipped bool
+	Reason  string
+	Err     error
+}
+
+type MaintenanceOptions struct {
+	Tasks []MaintenanceTask
+
+	// Result handler. Default: log
+	OnResult func(MaintenanceResult)
+}
+
+type Maintenance struct {
+	client    *ManticoreClient
+	opt       MaintenanceOptions
+	schedules []McSchedule
+
+	// running tasks per table
+	mu      sync.Mutex
+	running map[string]bool
+	last    map[int]MaintenanceResult
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func (m *ManticoreClient) NewMaintenance(opt MaintenanceOptions) (*Maintenance, error) {
+	schedules := make([]McSchedule, 0, len(opt.Tasks))
+	for _, task := range opt.Tasks {
+		switch task.Action {
+		case MaintenanceOptimize, MaintenanceFlush, MaintenanceReconfigure:
+			if !sqlIdentifier.MatchString(task.Table) {
+				return nil, fmt.Errorf("invalid table name: %q", task.Table)
+			}
+		case MaintenanceFlushAttributes:
+		default:
+			return nil, fmt.Errorf("unknown maintenance action: %q", task.Action)
+		}
+
+		schedule, err := ParseSchedule(task.Schedule)
+		if err != nil {
+			return nil, err
+		}
+
+		schedules = append(schedules, schedule)
+	}
+
+	if opt.OnResult == nil {
+		opt.OnResult = func(r MaintenanceResult) {
+			log.Printf("manticore maintenance: table=%s action=%s took=%s skipped=%t reason=%q err=%v",
+				r.Task.Table, r.Task.Action, r.End.Sub(r.Start), r.Skipped, r.Reason, r.Err)
+		}
+	}
+
+	return &Maintenance{
+		client:    m,
+		opt:       opt,
+		schedules: schedules,
+		running:   map[string]bool{},
+		last:      map[int]MaintenanceResult{},
+	}, nil
+}
+
+// Start scheduler in background until Stop or ctx is done
+func (mt *Maintenance) Start(ctx context.Context) error {
+	mt.mu.Lock()
+	defer mt.mu.Unlock()
+
+	if mt.cancel != nil {
+		return errors.New("maintenance already started")
+	}
+
+	if mt.client.IsReadOnly() {
+		return errors.New("readonly mode active")
+	}
+
+	ctx, mt.cancel = context.WithCancel(ctx)
+
+	mt.wg.Add(1)
+	go mt.run(ctx)
+
+	return nil
+}
+
+// Stop scheduler and wait for running tasks
+func (mt *Maintenance) Stop() {
+	mt.mu.Lock()
+	cancel := mt.cancel
+	mt.cancel = nil
+	mt.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+	}
+
+	mt.wg.Wait()
+}
+
+// Last result of every task that ran
+func (mt *Maintenance) Results() []MaintenanceResult {
+	mt.mu.Lock()
+	defer mt.mu.Unlock()
+
+	results := []MaintenanceResult{}
+	for i := range mt.opt.Tasks {
+		if r, ok := mt.last[i]; ok {
+			results = append(results, r)
+		}
+	}
+
+	return results
+}
+
+// Run task now, synchronously
+func (mt *Maintenance) RunTask(ctx context.Context, index int) (MaintenanceResult, error) {
+	if index < 0 || index >= len(mt.opt.Tasks) {
+		return MaintenanceResult{}, errors.New("invalid task index")
+	}
+
+	return mt.execute(ctx, index), nil
+}
+
+func (mt *Maintenance) run(ctx context.Context) {
+	defer mt.wg.Done()
+
+	next := make([]time.Time, len(mt.schedules))
+	now := time.Now()
+	for i := range mt.schedules {
+		next[i] = mt.next(i, now)
+	}
+
+	for {
+		// earliest activation
+		wake := time.Time{}
+		for _, t := range next {
+			if !t.IsZero() && (wake.IsZero() || t.Before(wake)) {
+				wake = t
+			}
+		}
+
+		if wake.IsZero() {
+			return
+		}
+
+		timer := time.NewTimer(time.Until(wake))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		now := time.Now()
+		for i, t := range next {
+			if t.IsZero() || t.After(now) {
+				continue
+			}
+
+			next[i] = mt.next(i, now)
+
+			mt.wg.Add(1)
+			go func(i int) {
+				defer mt.wg.Done()
+				mt.execute(ctx, i)
+			}(i)
+		}
+	}
+}
+
+// Next activation of task, a schedule without one is reported once and disabled
+func (mt *Maintenance) next(index int, now time.Time) time.Time {
+	t := mt.schedules[index].Next(now)
+	if t.IsZero() {
+		mt.opt.OnResult(MaintenanceResult{
+			Task:    mt.opt.Tasks[index],
+			Start:   now,
+			End:     now,
+			Skipped: true,
+			Err:     fmt.Errorf("schedule %q has no next activation", mt.opt.Tasks[index].Schedule),
+		})
+	}
+
+	return t
+}
+
+func (mt *Maintenance) execute(ctx context.Context, index int) MaintenanceResult {
+	task := mt.opt.Tasks[index]
+	result := MaintenanceResult{
+		Task:  task,
+		Start: time.Now(),
+	}
+
+	if mt.acquire(task.Table) {
+		defer mt.release(task.Table)
+
+		result.Err = mt.apply(ctx, task, &result)
+	} else {
+		result.Skipped = true
+		result.Reason = "another task of the table is running"
+	}
+
+	result.End = time.Now()
+
+	mt.mu.Lock()
+	mt.last[index] = result
+	mt.mu.Unlock()
+
+	mt.opt.OnResult(result)
+
+	return result
+}
+
+func (mt *Maintenance) apply(ctx context.Context, task MaintenanceTask, result *MaintenanceResult) error {
+	if mt.client.IsReadOnly() {
+		return errors.New("readonly mode active")
+	}
+
+	cmd := ""
+	switch task.Action {
+	case MaintenanceOptimize:
+		optimize, reason, err := mt.shouldOptimize(ctx, task)
+		if err != nil {
+			return err
+		}
+
+		result.Reason = reason
+		if !optimize {
+			result.Skipped = true
+			return nil
+		}
+
+		cmd = fmt.Sprintf("OPTIMIZE TABLE %s OPTION sync=%d", task.Table, boolInt(task.Sync))
+		if task.Cutoff > 0 {
+			cmd = fmt.Sprintf("%s,cutoff=%d", cmd, task.Cutoff)
+		}
+	case MaintenanceFlush:
+		cmd = fmt.Sprintf("FLUSH TABLE %s", task.Table)
+	case MaintenanceFlushAttributes:
+		cmd = "FLUSH ATTRIBUTES"
+	case MaintenanceReconfigure:
+		cmd = fmt.Sprintf("ALTER TABLE %s RECONFIGURE", task.Table)
+	}
+
+	_, err := mt.client.RunCliContext(ctx, []byte(cmd))
+
+	return err
+}
+
+// Optimize decision from SHOW TABLE STATUS
+func (mt *Maintenance) shouldOptimize(ctx context.Context, task MaintenanceTask) (bool, string, error) {
+	if task.MinDiskChunks <= 0 && task.MinRamBytes <= 0 {
+		return true, "no thresholds", nil
+	}
+
+	status, err := mt.client.GetTableStatus(ctx, task.Table)
+	if err != nil {
+		return false, "", err
+	}
+
+	if status.Optimizing {
+		return false, "optimize already running", nil
+	}
+
+	diskChunks, ramBytes := status.DiskChunks, status.RamBytes
+
+	if task.MinDiskChunks > 0 && diskChunks > task.MinDiskChunks {
+		return true, fmt.Sprintf("disk_chunks %d > %d", diskChunks, task.MinDiskChunks), nil
+	}
+
+	if task.MinRamBytes > 0 && ramBytes >= task.MinRamBytes {
+		return true, fmt.Sprintf("ram_bytes %d >= %d", ramBytes, task.MinRamBytes), nil
+	}
+
+	return false, fmt.Sprintf("disk_chunks %d, ram_bytes %d below thresholds", diskChunks, ramBytes), nil
+}
+
+func (mt *Maintenance) acquire(table string) bool {
+	mt.mu.Lock()
+	defer mt.mu.Unlock()
+
+	if mt.running[table] {
+		return false
+	}
+
+	mt.running[table] = true
+
+	return true
+}
+
+func (mt *Maintenance) release(table string) {
+	mt.mu.Lock()
+	defer mt.mu.Unlock()
+
+	delete(mt.running, table)
+}
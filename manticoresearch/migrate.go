@@ -0,0 +1,394 @@
+package manticoresearch
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/*
+Schema Migrations
+
+Migrate compares desired schemas with live tables and builds a plan:
+- missing table -> CREATE TABLE
+- missing column -> ALTER TABLE t ADD COLUMN c type
+- extra column -> ALTER TABLE t DROP COLUMN c (only with AllowDrop)
+- changed setting -> ALTER TABLE t key='value' (sizes compared in bytes: 128M = 134217728)
+
+Column type/property changes, engine changes and tokenization settings (morphology, charset_table, min_infix_len...)
+can not be applied to existing documents. These steps are flagged with RequiresRebuild and never applied: use Reindex.
+
+Applied plans are recorded per table in a bookkeeping table:
+-> CREATE TABLE IF NOT EXISTS mc_schema_migrations(table_name string, version string, statements text stored, applied_at timestamp)
+*/
+const DefaultMigrationsTable = "mc_schema_migrations"
+
+const (
+	MigrationActionCreate     = "create"
+	MigrationActionAddColumn  = "add_column"
+	MigrationActionDropColumn = "drop_column"
+	MigrationActionSetting    = "setting"
+	MigrationActionRebuild    = "rebuild"
+)
+
+var sizeSettingPattern = regexp.MustCompile(`^(\d+)([KkMmGg]?)$`)
+
+// Settings changing the tokenization or storage of existing documents
+var rebuildSettings = map[string]bool{
+	TableSettingType:        true,
+	TableSettingEngine:      true,
+	TableSettingDict:        true,
+	TableSettingMorphology:  true,
+	TableSettingMinInfixLen: true,
+	TableSettingMinPrefix:   true,
+	TableSettingCharset:     true,
+	TableSettingStopwords:   true,
+	TableSettingHtmlStrip:   true,
+	TableSettingIndexExact:  true,
+	"wordforms":             true,
+	"exceptions":            true,
+	"blend_chars":           true,
+	"ngram_len":             true,
+	"ngram_chars":           true,
+	"ignore_chars":          true,
+	"min_word_len":          true,
+	"index_sp":              true,
+	"index_zones":           true,
+}
+
+type MigrateOptions struct {
+	// Print plan only
+	DryRun bool
+
+	// Drop live columns missing in desired schema
+	AllowDrop bool
+
+	// Recorded version. Default: hash of desired schema
+	Version string
+
+	// Bookkeeping table. Default: mc_schema_migrations
+	MigrationsTable string
+}
+
+type MigrationStep struct {
+	Table     string
+	Action    string
+	Statement string
+
+	// Not applied: table must be rebuilt (Reindex)
+	RequiresRebuild bool
+	Reason          string
+}
+
+type MigrationPlan struct {
+	Steps   []MigrationStep
+	Applied bool
+}
+
+func (p MigrationPlan) Empty() bool {
+	return len(p.Steps) == 0
+}
+
+func (p MigrationPlan) RequiresRebuild() bool {
+	for _, step := range p.Steps {
+		if step.RequiresRebuild {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p MigrationPlan) String() string {
+	if p.Empty() {
+		return "no changes"
+	}
+
+	lines := []string{}
+	for _, step := range p.Steps {
+		line := fmt.Sprintf("[%s] %s: %s", step.Table, step.Action, step.Statement)
+		if step.RequiresRebuild {
+			line = fmt.Sprintf("[%s] %s (requires rebuild): %s", step.Table, step.Action, step.Reason)
+		}
+
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (m *ManticoreClient) Migrate(ctx context.Context, desired []TableSchema, opt MigrateOptions) (*MigrationPlan, error) {
+	if opt.MigrationsTable == "" {
+		opt.MigrationsTable = DefaultMigrationsTable
+	}
+
+	if !opt.DryRun && m.IsReadOnly() {
+		return nil, errors.New("readonly mode active")
+	}
+
+	live, err := m.liveTables(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &MigrationPlan{}
+	for _, schema := range desired {
+		if _, ok := live[schema.Name]; !ok {
+			stmt, err := schema.CreateTableSQL(true)
+			if err != nil {
+				return nil, err
+			}
+
+			plan.Steps = append(plan.Steps, MigrationStep{Table: schema.Name, Action: MigrationActionCreate, Statement: stmt})
+			continue
+		}
+
+		current, err := m.GetSchema(ctx, schema.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		steps, err := DiffSchema(*current, schema, opt.AllowDrop)
+		if err != nil {
+			return nil, err
+		}
+
+		plan.Steps = append(plan.Steps, steps...)
+	}
+
+	if opt.DryRun || plan.Empty() {
+		return plan, nil
+	}
+
+	if err := m.applyMigrationPlan(ctx, plan, desired, opt); err != nil {
+		return plan, err
+	}
+
+	plan.Applied = true
+
+	return plan, nil
+}
+
+// Steps migrating current schema to desired schema
+func DiffSchema(current TableSchema, desired TableSchema, allowDrop bool) ([]MigrationStep, error) {
+	steps := []MigrationStep{}
+	table := desired.Name
+
+	for _, field := range desired.Fields {
+		live, ok := current.Field(field.Name)
+		if !ok {
+			def, err := field.Definition()
+			if err != nil {
+				return nil, err
+			}
+
+			steps = append(steps, MigrationStep{
+				Table:     table,
+				Action:    MigrationActionAddColumn,
+				Statement: fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s", table, def),
+			})
+			continue
+		}
+
+		if reason := fieldChange(live, field); reason != "" {
+			steps = append(steps, MigrationStep{
+				Table:           table,
+				Action:          MigrationActionRebuild,
+				RequiresRebuild: true,
+				Reason:          reason,
+			})
+		}
+	}
+
+	if allowDrop {
+		for _, field := range current.Fields {
+			if _, ok := desired.Field(field.Name); !ok {
+				steps = append(steps, MigrationStep{
+					Table:     table,
+					Action:    MigrationActionDropColumn,
+					Statement: fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s", table, field.Name),
+				})
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(desired.Settings))
+	for key := range desired.Settings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := desired.Settings[key]
+		if normalizeSetting(current.Settings[key]) == normalizeSetting(value) {
+			continue
+		}
+
+		// rt is the default table type
+		if key == TableSettingType && value == TableTypeRT && current.Settings[key] == "" {
+			continue
+		}
+
+		if !sqlIdentifier.MatchString(key) {
+			return nil, fmt.Errorf("invalid setting name: %q", key)
+		}
+
+		step := MigrationStep{
+			Table:     table,
+			Action:    MigrationActionSetting,
+			Statement: fmt.Sprintf("ALTER TABLE %s %s=%s", table, key, sqlQuote(value)),
+		}
+
+		if rebuildSettings[key] {
+			step.Action = MigrationActionRebuild
+			step.RequiresRebuild = true
+			step.Reason = fmt.Sprintf("setting %s changed from %q to %q", key, current.Settings[key], value)
+		}
+
+		steps = append(steps, step)
+	}
+
+	return steps, nil
+}
+
+// Sizes in bytes, as SHOW CREATE TABLE reports them: "128M" -> "134217728"
+func normalizeSetting(value string) string {
+	value = strings.TrimSpace(value)
+
+	match := sizeSettingPattern.FindStringSubmatch(value)
+	if match == nil {
+		return value
+	}
+
+	n, err := strconv.ParseInt(match[1], 10, 64)
+	if err != nil {
+		return value
+	}
+
+	switch strings.ToUpper(match[2]) {
+	case "K":
+		n <<= 10
+	case "M":
+		n <<= 20
+	case "G":
+		n <<= 30
+	}
+
+	return strconv.FormatInt(n, 10)
+}
+
+// Description of an incompatible column change, empty if compatible
+func fieldChange(live TableField, desired TableField) string {
+	if live.Type != desired.Type {
+		return fmt.Sprintf("column %s type changed from %s to %s", desired.Name, live.Type, desired.Type)
+	}
+
+	switch desired.Type {
+	case FieldTypeText:
+		if live.Indexed != desired.Indexed || live.Stored != desired.Stored {
+			return fmt.Sprintf("text field %s indexed/stored properties changed", desired.Name)
+		}
+	case FieldTypeString:
+		if live.Indexed != desired.Indexed {
+			return fmt.Sprintf("string attribute %s indexed property changed", desired.Name)
+		}
+	case FieldTypeFloatVector:
+		if live.KnnDims != desired.KnnDims {
+			return fmt.Sprintf("float_vector %s knn_dims changed from %d to %d", desired.Name, live.KnnDims, desired.KnnDims)
+		}
+
+		if desired.HnswSimilarity != "" && !strings.EqualFold(live.HnswSimilarity, desired.HnswSimilarity) {
+			return fmt.Sprintf("float_vector %s hnsw_similarity changed", desired.Name)
+		}
+	}
+
+	if desired.Engine != "" && live.Engine != "" && live.Engine != desired.Engine {
+		return fmt.Sprintf("column %s engine changed from %s to %s", desired.Name, live.Engine, desired.Engine)
+	}
+
+	return ""
+}
+
+func (m *ManticoreClient) applyMigrationPlan(ctx context.Context, plan *MigrationPlan, desired []TableSchema, opt MigrateOptions) error {
+	bookkeeping := NewTableSchema(opt.MigrationsTable).
+		AddString("table_name", false).
+		AddString("version", false).
+		AddText("statements", false, true).
+		AddAttribute("applied_at", FieldTypeTimestamp)
+
+	if _, err := m.CreateTable(ctx, *bookkeeping, true); err != nil {
+		return err
+	}
+
+	applied := map[string][]string{}
+	for _, step := range plan.Steps {
+		if step.RequiresRebuild {
+			continue
+		}
+
+		if _, err := m.RunCliContext(ctx, []byte(step.Statement)); err != nil {
+			return fmt.Errorf("%s: %w", step.Statement, err)
+		}
+
+		applied[step.Table] = append(applied[step.Table], step.Statement)
+	}
+
+	for _, schema := range desired {
+		statements, ok := applied[schema.Name]
+		if !ok {
+			continue
+		}
+
+		version := opt.Version
+		if version == "" {
+			version = schemaVersion(schema)
+		}
+
+		record := fmt.Sprintf("INSERT INTO %s (table_name, version, statements, applied_at) VALUES (%s, %s, %s, %d)",
+			opt.MigrationsTable,
+			sqlQuote(schema.Name),
+			sqlQuote(version),
+			sqlQuote(strings.Join(statements, ";\n")),
+			time.Now().Unix(),
+		)
+
+		if _, err := m.RunCliContext(ctx, []byte(record)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Short hash of CREATE TABLE statement
+func schemaVersion(schema TableSchema) string {
+	stmt, _ := schema.CreateTableSQL(false)
+	sum := sha1.Sum([]byte(stmt))
+
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// Live table names and types from SHOW TABLES
+func (m *ManticoreClient) liveTables(ctx context.Context) (map[string]string, error) {
+	resp, err := m.RunCliContext(ctx, []byte("SHOW TABLES"))
+	if err != nil {
+		return nil, err
+	}
+
+	tables := map[string]string{}
+	for _, row := range resp.Rows() {
+		name := rowString(row, rowKey(row, "Index", "Table"))
+		if name != "" {
+			tables[name] = strings.ToLower(rowString(row, rowKey(row, "Type")))
+		}
+	}
+
+	return tables, nil
+}
@@ -0,0 +1,145 @@
+package manticoresearch
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffSchema(t *testing.T) {
+	live := func() *TableSchema {
+		return NewTableSchema("products").
+			AddText("title", true, true).
+			AddAttribute("price", FieldTypeFloat).
+			AddSetting(TableSettingRtMemLimit, "134217728").
+			AddSetting(TableSettingMorphology, "stem_en")
+	}
+
+	tests := []struct {
+		name      string
+		current   *TableSchema
+		desired   *TableSchema
+		allowDrop bool
+		want      []MigrationStep
+		wantErr   bool
+	}{
+		{
+			name:    "no changes",
+			current: live(),
+			desired: live(),
+			want:    []MigrationStep{},
+		},
+		{
+			name:    "rt_mem_limit with size suffix equals bytes",
+			current: live(),
+			desired: live().SetRtMemLimit("128M"),
+			want:    []MigrationStep{},
+		},
+		{
+			name:    "rt_mem_limit changed",
+			current: live(),
+			desired: live().SetRtMemLimit("256m"),
+			want: []MigrationStep{
+				{Table: "products", Action: MigrationActionSetting, Statement: "ALTER TABLE products rt_mem_limit='256m'"},
+			},
+		},
+		{
+			name:    "default rt type",
+			current: live(),
+			desired: live().AddSetting(TableSettingType, TableTypeRT),
+			want:    []MigrationStep{},
+		},
+		{
+			name:    "missing column",
+			current: live(),
+			desired: live().AddString("brand", true),
+			want: []MigrationStep{
+				{Table: "products", Action: MigrationActionAddColumn, Statement: "ALTER TABLE products ADD COLUMN brand string attribute indexed"},
+			},
+		},
+		{
+			name:    "extra column kept without allowDrop",
+			current: live().AddAttribute("stock", FieldTypeInt),
+			desired: live(),
+			want:    []MigrationStep{},
+		},
+		{
+			name:      "extra column dropped with allowDrop",
+			current:   live().AddAttribute("stock", FieldTypeInt),
+			desired:   live(),
+			allowDrop: true,
+			want: []MigrationStep{
+				{Table: "products", Action: MigrationActionDropColumn, Statement: "ALTER TABLE products DROP COLUMN stock"},
+			},
+		},
+		{
+			name:    "column type change requires rebuild",
+			current: live(),
+			desired: NewTableSchema("products").AddText("title", true, true).AddAttribute("price", FieldTypeBigint).AddSetting(TableSettingRtMemLimit, "128M").SetMorphology("stem_en"),
+			want: []MigrationStep{
+				{Table: "products", Action: MigrationActionRebuild, RequiresRebuild: true, Reason: "column price type changed from float to bigint"},
+			},
+		},
+		{
+			name:    "tokenization setting requires rebuild",
+			current: live(),
+			desired: live().SetMorphology("lemmatize_en_all"),
+			want: []MigrationStep{
+				{
+					Table:           "products",
+					Action:          MigrationActionRebuild,
+					Statement:       "ALTER TABLE products morphology='lemmatize_en_all'",
+					RequiresRebuild: true,
+					Reason:          `setting morphology changed from "stem_en" to "lemmatize_en_all"`,
+				},
+			},
+		},
+		{
+			name:    "invalid setting name",
+			current: live(),
+			desired: live().AddSetting("rt mem limit", "1"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DiffSchema(*tt.current, *tt.desired, tt.allowDrop)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DiffSchema() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DiffSchema() =\n%#v\nwant\n%#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeSetting(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"134217728", "134217728"},
+		{"128M", "134217728"},
+		{"128m", "134217728"},
+		{"512K", "524288"},
+		{"1G", "1073741824"},
+		{" 2 ", "2"},
+		{"stem_en", "stem_en"},
+		{"1.5G", "1.5G"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := normalizeSetting(tt.in); got != tt.want {
+				t.Errorf("normalizeSetting(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
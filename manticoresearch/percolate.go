@@ -0,0 +1,460 @@
+package manticoresearch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+/*
+Percolate Queries (PQ)
+Via: https://manual.manticoresearch.com/Searching/Percolate_query
+
+Stored queries live in percolate tables:
+-> CREATE TABLE products(title text, meta json) type='pq'
+
+Store query:
+PUT /pq/{pq_table_name}/doc/{?id}?refresh=1
+
+	{
+		"query": { "match": { "title": "bag" } },  // or { "ql": "@title bag" }
+		"tags": ["bags", "leather"],
+		"filters": "price > 3"
+	}
+
+Percolate documents (which stored queries match my documents?):
+POST /pq/{pq_table_name}/search
+
+	{ "query": { "percolate": { "document": { "title": "nice pair of shoes" } } } }
+	{ "query": { "percolate": { "documents": [ { "title": "a" }, { "title": "b" } ] } } }
+*/
+type MCPercolateQueryRequest struct {
+	// pq table name
+	Index string `json:"-"`
+
+	// Stored query ID (0: auto id)
+	Id uint64 `json:"-"`
+
+	// *McQueryOptions or {"ql": "..."}
+	Query interface{} `json:"query"`
+
+	Tags    []string `json:"tags,omitempty"`
+	Filters string   `json:"filters,omitempty"`
+}
+
+func NewMCPercolateQueryRequest(index string, query *McQueryOptions) MCPercolateQueryRequest {
+	return MCPercolateQueryRequest{
+		Index: index,
+		Query: query,
+	}
+}
+
+// Full-text query in SQL syntax: "@title bag"
+func NewMCPercolateQlRequest(index string, ql string) MCPercolateQueryRequest {
+	return MCPercolateQueryRequest{
+		Index: index,
+		Query: map[string]string{"ql": ql},
+	}
+}
+
+func (mc MCPercolateQueryRequest) AddId(id uint64) MCPercolateQueryRequest {
+	mc.Id = id
+
+	return mc
+}
+
+func (mc MCPercolateQueryRequest) AddTags(tags ...string) MCPercolateQueryRequest {
+	mc.Tags = append(mc.Tags, tags...)
+
+	return mc
+}
+
+// Attribute filters in SQL syntax: "price > 3 AND status = 1"
+func (mc MCPercolateQueryRequest) AddFilters(filters string) MCPercolateQueryRequest {
+	mc.Filters = filters
+
+	return mc
+}
+
+func (mc *MCPercolateQueryRequest) MarshalBinary() ([]byte, error) {
+	return json.Marshal(mc)
+}
+
+// Percolate Search
+type McPercolateQueryBuilder struct {
+	// pq table name
+	Index string `json:"-"`
+
+	Query McPercolateQuery `json:"query"`
+}
+
+type McPercolateQuery struct {
+	Percolate McPercolateDocuments `json:"percolate"`
+}
+
+type McPercolateDocuments struct {
+	Document  interface{}   `json:"document,omitempty"`
+	Documents []interface{} `json:"documents,omitempty"`
+}
+
+func NewMcPercolateQueryBuilder(index string) *McPercolateQueryBuilder {
+	return &McPercolateQueryBuilder{
+		Index: index,
+	}
+}
+
+// Single document, slot is 1
+func (qb *McPercolateQueryBuilder) SetDocument(doc interface{}) *McPercolateQueryBuilder {
+	qb.Query.Percolate.Document = doc
+	qb.Query.Percolate.Documents = nil
+
+	return qb
+}
+
+// Many documents, slots are 1-based positions of documents
+func (qb *McPercolateQueryBuilder) AddDocuments(docs ...interface{}) *McPercolateQueryBuilder {
+	if qb.Query.Percolate.Document != nil {
+		qb.Query.Percolate.Documents = append(qb.Query.Percolate.Documents, qb.Query.Percolate.Document)
+		qb.Query.Percolate.Document = nil
+	}
+
+	qb.Query.Percolate.Documents = append(qb.Query.Percolate.Documents, docs...)
+
+	return qb
+}
+
+func (qb *McPercolateQueryBuilder) MarshalBinary() ([]byte, error) {
+	return json.Marshal(qb)
+}
+
+// Percolate Responses
+type McPercolateSearchResponse struct {
+	Took     int                   `json:"took,omitempty"`
+	TimedOut bool                  `json:"timed_out,omitempty"`
+	Hits     *McPercolateHitsTotal `json:"hits,omitempty"`
+}
+
+type McPercolateHitsTotal struct {
+	Total int              `json:"total,omitempty"`
+	Hits  []McPercolateHit `json:"hits,omitempty"`
+}
+
+type McPercolateHit struct {
+	ID_    string      `json:"_id,omitempty"` // stored query id
+	Score_ interface{} `json:"_score,omitempty"`
+	Source struct {
+		Query   interface{} `json:"query,omitempty"`
+		Tags    string      `json:"tags,omitempty"`
+		Filters string      `json:"filters,omitempty"`
+	} `json:"_source,omitempty"`
+	Fields struct {
+		DocumentSlot []int `json:"_percolator_document_slot,omitempty"`
+	} `json:"fields,omitempty"`
+}
+
+// Matched stored query ids per document slot (1-based position of document)
+func (r *McPercolateSearchResponse) MatchesByDocument() map[int][]string {
+	matches := map[int][]string{}
+	if r.Hits == nil {
+		return matches
+	}
+
+	for _, hit := range r.Hits.Hits {
+		slots := hit.Fields.DocumentSlot
+		if len(slots) == 0 {
+			// single document mode
+			slots = []int{1}
+		}
+
+		for _, slot := range slots {
+			matches[slot] = append(matches[slot], hit.ID_)
+		}
+	}
+
+	return matches
+}
+
+// Stored Query (SELECT * FROM pq_table)
+type McPqStoredQuery struct {
+	Id      uint64
+	Query   string
+	Tags    string
+	Filters string
+}
+
+/*
+Endpoint: PUT /pq/{pq_table_name}/doc/{?id} JSON
+
+Stores a new query. Returns an error if the id already exists.
+*/
+func (m *ManticoreClient) InsertPq(item MCPercolateQueryRequest) (resp *MCPercolateResponse, err error) {
+	return m.InsertPqContext(context.Background(), item)
+}
+func (m *ManticoreClient) InsertPqContext(ctx context.Context, item MCPercolateQueryRequest) (resp *MCPercolateResponse, err error) {
+	return m.putPq(ctx, item, false)
+}
+
+/*
+Endpoint: PUT /pq/{pq_table_name}/doc/{id}?refresh=1 JSON
+
+Replaces the stored query with the same id.
+*/
+func (m *ManticoreClient) ReplacePq(item MCPercolateQueryRequest) (resp *MCPercolateResponse, err error) {
+	return m.ReplacePqContext(context.Background(), item)
+}
+func (m *ManticoreClient) ReplacePqContext(ctx context.Context, item MCPercolateQueryRequest) (resp *MCPercolateResponse, err error) {
+	if item.Id == 0 {
+		return nil, errors.New("stored query id required")
+	}
+
+	return m.putPq(ctx, item, true)
+}
+
+// refresh=1 creates the stored query if it does not exist
+func (m *ManticoreClient) UpsertPq(item MCPercolateQueryRequest) (resp *MCPercolateResponse, err error) {
+	return m.UpsertPqContext(context.Background(), item)
+}
+func (m *ManticoreClient) UpsertPqContext(ctx context.Context, item MCPercolateQueryRequest) (resp *MCPercolateResponse, err error) {
+	return m.putPq(ctx, item, item.Id > 0)
+}
+
+func (m *ManticoreClient) DeletePq(v MCDocumentDeleteRequest) (resp *MCDocumentResponse, err error) {
+	return m.Delete(v)
+}
+
+// Delete stored queries by id
+func (m *ManticoreClient) DeletePqQueries(tableName string, ids ...uint64) (resp *MCDocumentMainResponse, err error) {
+	return m.DeletePqQueriesContext(context.Background(), tableName, ids...)
+}
+func (m *ManticoreClient) DeletePqQueriesContext(ctx context.Context, tableName string, ids ...uint64) (resp *MCDocumentMainResponse, err error) {
+	if m.IsReadOnly() {
+		return nil, errors.New("readonly mode active")
+	}
+
+	if len(ids) == 0 {
+		return nil, errors.New("stored query ids required")
+	}
+
+	list := make([]string, 0, len(ids))
+	for _, id := range ids {
+		list = append(list, strconv.FormatUint(id, 10))
+	}
+
+	return m.RunCliContext(ctx, []byte(fmt.Sprintf("DELETE FROM %s WHERE id IN (%s)", m.clusterTable(tableName), strings.Join(list, ","))))
+}
+
+// Delete stored queries having any of given tags
+func (m *ManticoreClient) DeletePqByTags(tableName string, tags ...string) (resp *MCDocumentMainResponse, err error) {
+	return m.DeletePqByTagsContext(context.Background(), tableName, tags...)
+}
+func (m *ManticoreClient) DeletePqByTagsContext(ctx context.Context, tableName string, tags ...string) (resp *MCDocumentMainResponse, err error) {
+	if m.IsReadOnly() {
+		return nil, errors.New("readonly mode active")
+	}
+
+	if len(tags) == 0 {
+		return nil, errors.New("tags required")
+	}
+
+	return m.RunCliContext(ctx, []byte(fmt.Sprintf("DELETE FROM %s WHERE tags ANY (%s)", m.clusterTable(tableName), sqlQuoteList(tags))))
+}
+
+// List stored queries
+func (m *ManticoreClient) ListPq(tableName string, offset int, limit int) ([]McPqStoredQuery, error) {
+	return m.ListPqContext(context.Background(), tableName, offset, limit)
+}
+func (m *ManticoreClient) ListPqContext(ctx context.Context, tableName string, offset int, limit int) ([]McPqStoredQuery, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+
+	resp, err := m.RunCliContext(ctx, []byte(fmt.Sprintf("SELECT * FROM %s LIMIT %d,%d", tableName, offset, limit)))
+	if err != nil {
+		return nil, err
+	}
+
+	queries := []McPqStoredQuery{}
+	for _, row := range resp.Rows() {
+		queries = append(queries, McPqStoredQuery{
+			Id:      rowUint64(row, "id"),
+			Query:   rowString(row, "query"),
+			Tags:    rowString(row, "tags"),
+			Filters: rowString(row, "filters"),
+		})
+	}
+
+	return queries, nil
+}
+
+/*
+Endpoint: POST /pq/{pq_table_name}/search JSON
+*/
+func (m *ManticoreClient) SearchPq(builder *McPercolateQueryBuilder) (resp *McPercolateSearchResponse, err error) {
+	return m.SearchPqContext(context.Background(), builder)
+}
+func (m *ManticoreClient) SearchPqContext(ctx context.Context, builder *McPercolateQueryBuilder) (resp *McPercolateSearchResponse, err error) {
+	// payload
+	payload, _ := builder.MarshalBinary()
+
+	// Request
+	code, body, err := m.client.PostJSONContext(ctx, m.generateUrl([]string{MCApiRoutePq, builder.Index, MCApiRouteSearch}), payload)
+	if err != nil {
+		return nil, err
+	}
+
+	if m.client.debug {
+		fmt.Printf("\nBody: %s - Status: %d\n", string(body), code)
+	}
+
+	// catch error json
+	singleError := MCDocumentErrorResponse{}
+	if err := json.Unmarshal(body, &singleError); err == nil && singleError.Error != "" {
+		return nil, errors.New(singleError.Error)
+	}
+
+	err = json.Unmarshal(body, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+/*
+CALL PQ - docs_json batch mode
+
+-> CALL PQ('pq_table', ('{"title":"a"}', '{"title":"b"}'), 1 as docs_json, 1 as docs, 1 as query)
+
+Returns one row per matched stored query with matched document numbers (1-based, or ids from "shift"/"docs_id")
+*/
+type McCallPqOptions struct {
+	Docs        bool   // return matched document numbers
+	Query       bool   // return stored query info
+	Verbose     bool   // extended info (timings) in SHOW META
+	SkipBadJson bool   // skip invalid json documents instead of failing
+	Shift       int    // added to document numbers
+	DocsId      string // document attribute to return instead of document number
+}
+
+type McPqMatch struct {
+	Id        uint64 // stored query id
+	Documents []int64
+	Query     string
+	Tags      string
+	Filters   string
+}
+
+func (m *ManticoreClient) CallPq(tableName string, docs []interface{}, opt McCallPqOptions) ([]McPqMatch, error) {
+	return m.CallPqContext(context.Background(), tableName, docs, opt)
+}
+func (m *ManticoreClient) CallPqContext(ctx context.Context, tableName string, docs []interface{}, opt McCallPqOptions) ([]McPqMatch, error) {
+	if len(docs) == 0 {
+		return nil, errors.New("documents required")
+	}
+
+	items := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		data, err := json.Marshal(doc)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, string(data))
+	}
+
+	args := []string{
+		sqlQuote(tableName),
+		fmt.Sprintf("(%s)", sqlQuoteList(items)),
+		"1 as docs_json",
+		fmt.Sprintf("%d as docs", boolInt(opt.Docs)),
+		fmt.Sprintf("%d as query", boolInt(opt.Query)),
+		fmt.Sprintf("%d as verbose", boolInt(opt.Verbose)),
+		fmt.Sprintf("%d as skip_bad_json", boolInt(opt.SkipBadJson)),
+	}
+
+	if opt.Shift > 0 {
+		args = append(args, fmt.Sprintf("%d as shift", opt.Shift))
+	}
+
+	if opt.DocsId != "" {
+		args = append(args, fmt.Sprintf("%s as docs_id", sqlQuote(opt.DocsId)))
+	}
+
+	resp, err := m.RunCliContext(ctx, []byte(fmt.Sprintf("CALL PQ(%s)", strings.Join(args, ", "))))
+	if err != nil {
+		return nil, err
+	}
+
+	matches := []McPqMatch{}
+	for _, row := range resp.Rows() {
+		match := McPqMatch{
+			Id:      rowUint64(row, "id"),
+			Query:   rowString(row, "query"),
+			Tags:    rowString(row, "tags"),
+			Filters: rowString(row, "filters"),
+		}
+
+		for _, doc := range strings.Split(rowString(row, "documents"), ",") {
+			if n, err := strconv.ParseInt(strings.TrimSpace(doc), 10, 64); err == nil {
+				match.Documents = append(match.Documents, n)
+			}
+		}
+
+		matches = append(matches, match)
+	}
+
+	return matches, nil
+}
+
+func (m *ManticoreClient) putPq(ctx context.Context, item MCPercolateQueryRequest, refresh bool) (resp *MCPercolateResponse, err error) {
+	if m.IsReadOnly() {
+		return nil, errors.New("readonly mode active")
+	}
+
+	args := []string{MCApiRoutePq, m.clusterTable(item.Index), "doc"}
+	if item.Id > 0 {
+		args = append(args, strconv.FormatUint(item.Id, 10))
+	}
+
+	url := m.generateUrl(args)
+	if refresh {
+		url += "?refresh=1"
+	}
+
+	// payload
+	payload, _ := item.MarshalBinary()
+
+	// Request
+	code, body, err := m.client.PutJSONContext(ctx, url, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	if m.client.debug {
+		fmt.Printf("\nBody: %s - Status: %d\n", string(body), code)
+	}
+
+	// catch error json
+	singleError := MCDocumentErrorResponse{}
+	if err := json.Unmarshal(body, &singleError); err == nil && singleError.Error != "" {
+		return nil, errors.New(singleError.Error)
+	}
+
+	err = json.Unmarshal(body, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+
+	return 0
+}
@@ -1,5 +1,7 @@
 package manticoresearch
 
+import "fmt"
+
 // Aggregation Options - Facet Query Options
 type McAggregation struct {
 	Terms McAggregationTerms `json:"terms"`
@@ -41,7 +43,20 @@ func (qb *McSearchQueryBuilder) AddAgg(groupName string, field string, size int)
 }
 
 func (qb *McSearchQueryBuilder) AddExp(field string, exp string) *McSearchQueryBuilder {
+	// check expressions is set
+	if len(qb.Expressions) == 0 {
+		qb.Expressions = map[string]string{}
+	}
+
 	qb.Expressions[field] = exp
 
 	return qb
 }
+
+// Computed distance (in meters) from anchor, returned in hit source as "geodist"
+// "expressions": {"geodist": "GEODIST(attr_lat, attr_lon, 49, 15, {in=degrees, out=meters})"}
+const McGeoDistanceExpName = "geodist"
+
+func (qb *McSearchQueryBuilder) AddGeoDistExp(anchorLat, anchorLon float64, latAttr, lonAttr string) *McSearchQueryBuilder {
+	return qb.AddExp(McGeoDistanceExpName, fmt.Sprintf("GEODIST(%s, %s, %f, %f, {in=degrees, out=meters})", latAttr, lonAttr, anchorLat, anchorLon))
+}
@@ -0,0 +1,194 @@
+package manticoresearch
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+/*
+Query/Bool Clause Tree
+
+McQueryOptions.AddBool* methods can only push flat leaves into the top level bool sections.
+Clause tree builds bool queries with any depth and any leaf type:
+
+	Bool().
+		Must(
+			Match([]string{"title"}, "hello"),
+			Bool().Should(
+				Equals("status", 1),
+				Range("price", AtLeast(100)),
+			),
+		).
+		MustNot(MatchPhrase([]string{"content"}, "sold out"))
+
+Serialised:
+
+	"bool": {
+		"must": [
+			{ "match": { "title": "hello" } },
+			{ "bool": { "should": [
+				{ "equals": { "status": 1 } },
+				{ "range": { "price": { "gte": 100 } } }
+			] } }
+		],
+		"must_not": [
+			{ "match_phrase": { "content": "sold out" } }
+		]
+	}
+*/
+type McQueryClause interface {
+	Source() map[string]interface{}
+}
+
+// Leaf clause: { "<filter>": { "<field>": <value> } }
+type McLeafClause map[string]interface{}
+
+func (c McLeafClause) Source() map[string]interface{} {
+	return c
+}
+
+func newLeafClause(variousKey string, matchKey string, k string, v interface{}) McLeafClause {
+	if matchKey == McQueryMatchKeyAny || matchKey == McQueryMatchKeyAll {
+		k = fmt.Sprintf("%s(%s)", matchKey, k)
+	}
+
+	return McLeafClause{
+		variousKey: map[string]interface{}{
+			k: v,
+		},
+	}
+}
+
+// Match
+func Match(fields []string, keyword string) McLeafClause {
+	return newLeafClause(McQueryMatchFilterVariousMatch, "", strings.Join(fields, ","), keyword)
+}
+func MatchOperator(fields []string, keyword string, operator string) McLeafClause {
+	return newLeafClause(McQueryMatchFilterVariousMatch, "", strings.Join(fields, ","), McQueryMatchOperator{
+		Query:    keyword,
+		Operator: operator,
+	})
+}
+func MatchPhrase(fields []string, keyword string) McLeafClause {
+	return newLeafClause(McQueryMatchFilterVariousMatchPhrase, "", strings.Join(fields, ","), keyword)
+}
+func QueryString(queryString string) McLeafClause {
+	return McLeafClause{
+		McQueryMatchFilterVariousQueryString: queryString,
+	}
+}
+func MatchAll() McLeafClause {
+	return McLeafClause{
+		McQueryMatchFilterVariousMatchAll: map[string]interface{}{},
+	}
+}
+
+// Equals
+func Equals(k string, v interface{}) McLeafClause {
+	return newLeafClause(McQueryMatchFilterVariousEquals, "", k, v)
+}
+func EqualsAny(k string, v interface{}) McLeafClause {
+	return newLeafClause(McQueryMatchFilterVariousEquals, McQueryMatchKeyAny, k, v)
+}
+func EqualsAll(k string, v interface{}) McLeafClause {
+	return newLeafClause(McQueryMatchFilterVariousEquals, McQueryMatchKeyAll, k, v)
+}
+
+// In
+func In(k string, v interface{}) McLeafClause {
+	return newLeafClause(McQueryMatchFilterVariousIn, "", k, v)
+}
+func InAny(k string, v interface{}) McLeafClause {
+	return newLeafClause(McQueryMatchFilterVariousIn, McQueryMatchKeyAny, k, v)
+}
+func InAll(k string, v interface{}) McLeafClause {
+	return newLeafClause(McQueryMatchFilterVariousIn, McQueryMatchKeyAll, k, v)
+}
+
+// Range
+func Range(k string, v McRange) McLeafClause {
+	return newLeafClause(McQueryMatchFilterVariousRange, "", k, v)
+}
+
+// Geo Distance
+func GeoDistance(anchorLat, anchorLon float64, latAttr, lonAttr string, distance string, distanceType string) McLeafClause {
+	return McLeafClause{
+		McQueryMatchFilterVariousGeoDistance: NewMcQueryGeoDistance(anchorLat, anchorLon, latAttr, lonAttr, distance, distanceType),
+	}
+}
+
+// Bool: must, should and must_not sections. Each section can hold leaves or other bool queries
+type McBoolQuery struct {
+	must    []McQueryClause
+	should  []McQueryClause
+	mustNot []McQueryClause
+}
+
+func Bool() *McBoolQuery {
+	return &McBoolQuery{}
+}
+
+func (b *McBoolQuery) Must(clauses ...McQueryClause) *McBoolQuery {
+	b.must = append(b.must, clauses...)
+
+	return b
+}
+func (b *McBoolQuery) Should(clauses ...McQueryClause) *McBoolQuery {
+	b.should = append(b.should, clauses...)
+
+	return b
+}
+func (b *McBoolQuery) MustNot(clauses ...McQueryClause) *McBoolQuery {
+	b.mustNot = append(b.mustNot, clauses...)
+
+	return b
+}
+
+// Sections without "bool" wrapper
+func (b *McBoolQuery) Sections() map[string][]interface{} {
+	sections := map[string][]interface{}{}
+
+	for key, clauses := range map[string][]McQueryClause{
+		McQueryMatchFilterSectionMust:    b.must,
+		McQueryMatchFilterSectionShould:  b.should,
+		McQueryMatchFilterSectionMustNot: b.mustNot,
+	} {
+		for _, clause := range clauses {
+			sections[key] = append(sections[key], clause.Source())
+		}
+	}
+
+	return sections
+}
+
+func (b *McBoolQuery) Source() map[string]interface{} {
+	return map[string]interface{}{
+		McQueryMatchFilterBool: b.Sections(),
+	}
+}
+
+func (b *McBoolQuery) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b.Source())
+}
+
+// Replace top level bool query with clause tree
+func (qb *McQueryOptions) SetBool(b *McBoolQuery) *McQueryOptions {
+	sections := b.Sections()
+	qb.Bool = &sections
+
+	return qb
+}
+
+// Append clauses (leaf or nested bool) into top level bool section: must, should, must_not
+func (qb *McQueryOptions) AddBoolClause(sectionKey string, clauses ...McQueryClause) *McQueryOptions {
+	if qb.Bool == nil {
+		qb.Bool = &map[string][]interface{}{}
+	}
+
+	for _, clause := range clauses {
+		(*qb.Bool)[sectionKey] = append((*qb.Bool)[sectionKey], clause.Source())
+	}
+
+	return qb
+}
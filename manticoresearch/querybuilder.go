@@ -33,6 +33,9 @@ type McSearchQueryBuilder struct {
 	// Query Options
 	Query *McQueryOptions `json:"query,omitempty" redis:"query"`
 
+	// KNN vector search
+	Knn *McKnnOptions `json:"knn,omitempty" redis:"knn"`
+
 	// Source
 	// Each entry can be an attribute name or a wildcard (*, % and ? symbols are supported)
 	// "_source":"attr*",
@@ -41,7 +44,7 @@ type McSearchQueryBuilder struct {
 	Source interface{} `json:"_source,omitempty" redis:"_source"`
 
 	// Highlight
-	// Highlight interface{} `json:"highlight,omitempty" redis:"highlight"`
+	Highlight *McHighlightOptions `json:"highlight,omitempty" redis:"highlight"`
 
 	// Sorting
 	Sort interface{} `json:"sort,omitempty" redis:"sort"`
@@ -0,0 +1,111 @@
+package manticoresearch
+
+/*
+Highlight
+Via: https://manual.manticoresearch.com/Searching/Highlighting
+
+	"highlight": {
+		"fields": ["title", "content"],
+		"limit": 50,
+		"limit_words": 10,
+		"around": 3,
+		"pre_tags": "<b>",
+		"post_tags": "</b>",
+		"html_strip_mode": "strip",
+		"number_of_fragments": 2,
+		"highlight_query": { "match": { "*": "keyword" } }
+	}
+
+Highlighted snippets are returned per hit: "highlight": { "content": ["..<b>keyword</b>.."] }
+An empty highlight object highlights all full-text fields with default options.
+*/
+const (
+	McHighlightHtmlStripNone   = "none"
+	McHighlightHtmlStripStrip  = "strip"
+	McHighlightHtmlStripIndex  = "index"
+	McHighlightHtmlStripRetain = "retain"
+)
+
+// Numeric options are pointers: 0 is a meaningful value (e.g. around=0, limit=0 means no limit)
+type McHighlightOptions struct {
+	Fields []string `json:"fields,omitempty" redis:"fields"`
+
+	Limit             *int `json:"limit,omitempty" redis:"limit"`                             // maximum snippet size, in symbols. Default: 256
+	LimitWords        *int `json:"limit_words,omitempty" redis:"limit_words"`                 // maximum number of words in the result. Default: 0 (no limit)
+	LimitSnippets     *int `json:"limit_snippets,omitempty" redis:"limit_snippets"`           // maximum number of snippets in the result. Default: 0 (no limit)
+	Around            *int `json:"around,omitempty" redis:"around"`                           // how many words to pick around each matching keyword block. Default: 5
+	NumberOfFragments *int `json:"number_of_fragments,omitempty" redis:"number_of_fragments"` // maximum number of fragments per field. Default: 0 (no limit)
+
+	PreTags       string `json:"pre_tags,omitempty" redis:"pre_tags"`               // Default: <b>
+	PostTags      string `json:"post_tags,omitempty" redis:"post_tags"`             // Default: </b>
+	HtmlStripMode string `json:"html_strip_mode,omitempty" redis:"html_strip_mode"` // none, strip, index, retain
+
+	// Highlight against another query than the search query
+	HighlightQuery *McQueryOptions `json:"highlight_query,omitempty" redis:"highlight_query"`
+}
+
+func NewMcHighlightOptions() McHighlightOptions {
+	return McHighlightOptions{}
+}
+
+func (qb McHighlightOptions) AddFields(fields ...string) McHighlightOptions {
+	qb.Fields = append(qb.Fields, fields...)
+
+	return qb
+}
+
+func (qb McHighlightOptions) AddLimit(limit int) McHighlightOptions {
+	qb.Limit = &limit
+
+	return qb
+}
+
+func (qb McHighlightOptions) AddLimitWords(limit int) McHighlightOptions {
+	qb.LimitWords = &limit
+
+	return qb
+}
+
+func (qb McHighlightOptions) AddLimitSnippets(limit int) McHighlightOptions {
+	qb.LimitSnippets = &limit
+
+	return qb
+}
+
+func (qb McHighlightOptions) AddAround(around int) McHighlightOptions {
+	qb.Around = &around
+
+	return qb
+}
+
+func (qb McHighlightOptions) AddNumberOfFragments(count int) McHighlightOptions {
+	qb.NumberOfFragments = &count
+
+	return qb
+}
+
+func (qb McHighlightOptions) AddTags(pre string, post string) McHighlightOptions {
+	qb.PreTags = pre
+	qb.PostTags = post
+
+	return qb
+}
+
+func (qb McHighlightOptions) AddHtmlStripMode(mode string) McHighlightOptions {
+	qb.HtmlStripMode = mode
+
+	return qb
+}
+
+func (qb McHighlightOptions) AddHighlightQuery(query *McQueryOptions) McHighlightOptions {
+	qb.HighlightQuery = query
+
+	return qb
+}
+
+// Highlight
+func (qb *McSearchQueryBuilder) SetHighlight(opt McHighlightOptions) *McSearchQueryBuilder {
+	qb.Highlight = &opt
+
+	return qb
+}
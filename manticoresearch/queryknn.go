@@ -0,0 +1,156 @@
+package manticoresearch
+
+import (
+	"context"
+	"sort"
+)
+
+/*
+KNN Vector Search
+Via: https://manual.manticoresearch.com/Searching/KNN
+
+Table must have a float_vector attribute with knn settings:
+-> CREATE TABLE test (title text, image_vector float_vector knn_type='hnsw' knn_dims='4' hnsw_similarity='l2')
+
+	POST /search
+	{
+		"index": "test",
+		"knn": {
+			"field": "image_vector",
+			"query_vector": [0.1, 0.2, 0.3, 0.4],  // or "doc_id": 1 -> use vector of this document
+			"k": 5,
+			"ef": 2000,
+			"filter": { "equals": { "status": 1 } }
+		}
+	}
+
+Hits are sorted by distance, returned in "_knn_dist"
+*/
+type McKnnOptions struct {
+	Field       string          `json:"field" redis:"field"`
+	K           int             `json:"k" redis:"k"`
+	QueryVector []float32       `json:"query_vector,omitempty" redis:"query_vector"`
+	DocId       uint64          `json:"doc_id,omitempty" redis:"doc_id"`
+	Ef          int             `json:"ef,omitempty" redis:"ef"`
+	Filter      *McQueryOptions `json:"filter,omitempty" redis:"filter"`
+}
+
+func NewMcKnnOptions(field string, k int) McKnnOptions {
+	return McKnnOptions{
+		Field: field,
+		K:     k,
+	}
+}
+
+func (qb McKnnOptions) AddQueryVector(vector []float32) McKnnOptions {
+	qb.QueryVector = vector
+	qb.DocId = 0
+
+	return qb
+}
+
+// Search documents similar to the document with given id
+func (qb McKnnOptions) AddDocId(id uint64) McKnnOptions {
+	qb.DocId = id
+	qb.QueryVector = nil
+
+	return qb
+}
+
+// Size of the dynamic list for the nearest neighbors search. Higher is more accurate but slower
+func (qb McKnnOptions) AddEf(ef int) McKnnOptions {
+	qb.Ef = ef
+
+	return qb
+}
+
+// Attribute filters applied to the knn results
+func (qb McKnnOptions) AddFilter(filter *McQueryOptions) McKnnOptions {
+	qb.Filter = filter
+
+	return qb
+}
+
+func (qb *McSearchQueryBuilder) SetKnn(opt McKnnOptions) *McSearchQueryBuilder {
+	qb.Knn = &opt
+
+	return qb
+}
+
+/*
+Hybrid Search
+
+Runs full-text (BM25) and knn queries and merges both result lists with reciprocal rank fusion:
+
+	score(doc) = sum 1 / (k + rank)
+
+rank is the 1-based position of the document in each result list. k dampens the weight of top ranks (Default: 60).
+*/
+const DefaultMcRRFRankConstant = 60
+
+type McHybridHit struct {
+	Hit   McSearchResponseHitsHits
+	Score float64
+
+	// 1-based position in each result list, 0: not found in the list
+	TextRank int
+	KnnRank  int
+}
+
+func (m *ManticoreClient) HybridSearch(ctx context.Context, textBuilder *McSearchQueryBuilder, knnBuilder *McSearchQueryBuilder, rankConstant int) ([]McHybridHit, error) {
+	if rankConstant <= 0 {
+		rankConstant = DefaultMcRRFRankConstant
+	}
+
+	textResp, err := m.SearchContext(ctx, textBuilder)
+	if err != nil {
+		return nil, err
+	}
+
+	knnResp, err := m.SearchContext(ctx, knnBuilder)
+	if err != nil {
+		return nil, err
+	}
+
+	fused := map[string]*McHybridHit{}
+	order := []string{}
+	merge := func(resp *McSearchResponse, knn bool) {
+		if resp.Hits == nil {
+			return
+		}
+
+		for i, hit := range resp.Hits.Hits {
+			item, ok := fused[hit.ID_]
+			if !ok {
+				item = &McHybridHit{Hit: hit}
+				fused[hit.ID_] = item
+				order = append(order, hit.ID_)
+			}
+
+			if knn {
+				item.KnnRank = i + 1
+				item.Hit.KnnDist_ = hit.KnnDist_
+			} else {
+				item.TextRank = i + 1
+				item.Hit.Score_ = hit.Score_
+				item.Hit.Highlight = hit.Highlight
+			}
+
+			item.Score += 1 / float64(rankConstant+i+1)
+		}
+	}
+
+	merge(textResp, false)
+	merge(knnResp, true)
+
+	hits := make([]McHybridHit, 0, len(order))
+	for _, id := range order {
+		hits = append(hits, *fused[id])
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		return hits[i].Score > hits[j].Score
+	})
+
+	return hits, nil
+}
@@ -17,6 +17,9 @@ type McQueryOptions struct {
 	In     *map[string]interface{}   `json:"in,omitempty" redis:"in"`
 	Range  *map[string]McQueryRange  `json:"range,omitempty" redis:"range"`
 	Bool   *map[string][]interface{} `json:"bool,omitempty" redis:"bool"`
+
+	// Geo-distance filter
+	GeoDistance *McQueryGeoDistance `json:"geo_distance,omitempty" redis:"geo_distance"`
 }
 
 type McQueryMatchOperator struct {
@@ -210,7 +213,7 @@ Example:
 	    }
 		"geo_distance": {
 	      "location_anchor": {"lat":49, "lon":15},
-	      "location_source": {"attr_lat, attr_lon"},
+	      "location_source": {"lat": "attr_lat", "lon": "attr_lon"},
 	      "distance_type": "adaptive",
 	      "distance":"100 km"
 	    }
@@ -288,6 +291,8 @@ gte: greater than or equal to
 gt: greater than
 lte: less than or equal to
 lt: less than
+
+Note: McQueryRange only supports int bounds and zero bounds are omitted. Use AddRangeBounds for exact bounds.
 */
 func (qb *McQueryOptions) AddRange(k string, v McQueryRange) *McQueryOptions {
 	if qb.Range == nil {
@@ -299,7 +304,53 @@ func (qb *McQueryOptions) AddRange(k string, v McQueryRange) *McQueryOptions {
 	return qb
 }
 
-// TODO: Geo Distance - https://manual.manticoresearch.com/Searching/Filters#Geo-distance-filters
+// Range with exact bounds: qb.AddRangeBounds("price", Between(0.5, 10.0))
+// Added as a bool must filter, the Range field keeps McQueryRange values
+func (qb *McQueryOptions) AddRangeBounds(k string, v McRange) *McQueryOptions {
+	return qb.AddBoolClause(McQueryMatchFilterSectionMust, Range(k, v))
+}
+
+/*
+Geo Distance
+Via: https://manual.manticoresearch.com/Searching/Filters#Geo-distance-filters
+
+location_anchor: pin location, in degrees
+location_source: attributes containing latitude and longitude, in degrees
+distance_type: "adaptive" (faster, more precise) or "haversine"
+distance: "100 km", "2 mi", "500m" or number in meters
+*/
+const (
+	McGeoDistanceTypeAdaptive  = "adaptive"
+	McGeoDistanceTypeHaversine = "haversine"
+)
+
+type McGeoLocation struct {
+	Lat interface{} `json:"lat" redis:"lat"`
+	Lon interface{} `json:"lon" redis:"lon"`
+}
+
+type McQueryGeoDistance struct {
+	LocationAnchor McGeoLocation `json:"location_anchor" redis:"location_anchor"`
+	LocationSource McGeoLocation `json:"location_source" redis:"location_source"`
+	DistanceType   string        `json:"distance_type,omitempty" redis:"distance_type"`
+	Distance       string        `json:"distance" redis:"distance"`
+}
+
+func NewMcQueryGeoDistance(anchorLat, anchorLon float64, latAttr, lonAttr string, distance string, distanceType string) McQueryGeoDistance {
+	return McQueryGeoDistance{
+		LocationAnchor: McGeoLocation{Lat: anchorLat, Lon: anchorLon},
+		LocationSource: McGeoLocation{Lat: latAttr, Lon: lonAttr},
+		DistanceType:   distanceType,
+		Distance:       distance,
+	}
+}
+
+func (qb *McQueryOptions) AddGeoDistance(anchorLat, anchorLon float64, latAttr, lonAttr string, distance string, distanceType string) *McQueryOptions {
+	geo := NewMcQueryGeoDistance(anchorLat, anchorLon, latAttr, lonAttr, distance, distanceType)
+	qb.GeoDistance = &geo
+
+	return qb
+}
 
 /*
 Query/Bool
@@ -350,9 +401,14 @@ const (
 	McQueryMatchFilterSectionMustNot = "must_not"
 )
 const (
-	McQueryMatchFilterVariousMatch  = "match"
-	McQueryMatchFilterVariousEquals = "equals"
-	McQueryMatchFilterVariousIn     = "in"
+	McQueryMatchFilterVariousMatch       = "match"
+	McQueryMatchFilterVariousMatchPhrase = "match_phrase"
+	McQueryMatchFilterVariousQueryString = "query_string"
+	McQueryMatchFilterVariousMatchAll    = "match_all"
+	McQueryMatchFilterVariousEquals      = "equals"
+	McQueryMatchFilterVariousIn          = "in"
+	McQueryMatchFilterVariousRange       = "range"
+	McQueryMatchFilterVariousGeoDistance = "geo_distance"
 )
 const (
 	McQueryMatchKeyAny = "any"
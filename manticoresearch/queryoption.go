@@ -2,15 +2,16 @@ package manticoresearch
 
 // Options
 type McOptions struct {
-	AccurateAggregation int    `json:"accurate_aggregation,omitempty" redis:"accurate_aggregation"`
-	AgentQueryTimeout   int    `json:"agent_query_timeout,omitempty" redis:"agent_query_timeout"`
-	AgentRetryCount     int    `json:"agent_retry_count,omitempty" redis:"agent_retry_count"`
-	MirrorRetryCount    int    `json:"mirror_retry_count,omitempty" redis:"mirror_retry_count"`
-	AgentRetryDelay     int    `json:"agent_retry_delay,omitempty" redis:"agent_retry_delay"`
-	ClientTimeout       int    `json:"client_timeout,omitempty" redis:"client_timeout"`
-	HostnameLookup      string `json:"hostname_lookup,omitempty" redis:"hostname_lookup"`
-	ListenTfo           string `json:"listen_tfo,omitempty" redis:"listen_tfo"`
-	PersistentConnectionsLimit           int `json:"persistent_connections_limit,omitempty" redis:"persistent_connections_limit"`
+	AccurateAggregation        int    `json:"accurate_aggregation,omitempty" redis:"accurate_aggregation"`
+	AgentQueryTimeout          int    `json:"agent_query_timeout,omitempty" redis:"agent_query_timeout"`
+	AgentRetryCount            int    `json:"agent_retry_count,omitempty" redis:"agent_retry_count"`
+	MirrorRetryCount           int    `json:"mirror_retry_count,omitempty" redis:"mirror_retry_count"`
+	AgentRetryDelay            int    `json:"agent_retry_delay,omitempty" redis:"agent_retry_delay"`
+	ClientTimeout              int    `json:"client_timeout,omitempty" redis:"client_timeout"`
+	HostnameLookup             string `json:"hostname_lookup,omitempty" redis:"hostname_lookup"`
+	ListenTfo                  string `json:"listen_tfo,omitempty" redis:"listen_tfo"`
+	Comment                    string `json:"comment,omitempty" redis:"comment"`
+	PersistentConnectionsLimit int    `json:"persistent_connections_limit,omitempty" redis:"persistent_connections_limit"`
 }
 
 /*
@@ -114,3 +115,10 @@ func (qb McOptions) AddPersistentConnectionsLimit(limit int) McOptions {
 
 	return qb
 }
+
+// User comment, copied to the query log and visible in SHOW QUERIES.
+func (qb McOptions) AddComment(comment string) McOptions {
+	qb.Comment = comment
+
+	return qb
+}
@@ -0,0 +1,82 @@
+package manticoresearch
+
+import "time"
+
+/*
+Range Bounds
+
+Only the bounds set by the caller are sent, zero is a valid bound:
+
+	AtLeast(0)                            -> { "gte": 0 }
+	Between(9.99, 19.99)                  -> { "gte": 9.99, "lte": 19.99 }
+	GreaterThan(time.Unix(1700000000, 0)) -> { "gt": 1700000000 } // time.Time as epoch seconds
+
+Supported bound types: int, int64, uint64, float64 and time.Time (timestamp attributes)
+*/
+type McRangeValue interface {
+	~int | ~int64 | ~uint64 | ~float64 | time.Time
+}
+
+type McRange struct {
+	Gte interface{} `json:"gte,omitempty" redis:"gte"`
+	Gt  interface{} `json:"gt,omitempty" redis:"gt"`
+	Lte interface{} `json:"lte,omitempty" redis:"lte"`
+	Lt  interface{} `json:"lt,omitempty" redis:"lt"`
+}
+
+// gte: greater than or equal to
+func AtLeast[T McRangeValue](v T) McRange {
+	return McRange{Gte: rangeBound(v)}
+}
+
+// gt: greater than
+func GreaterThan[T McRangeValue](v T) McRange {
+	return McRange{Gt: rangeBound(v)}
+}
+
+// lte: less than or equal to
+func AtMost[T McRangeValue](v T) McRange {
+	return McRange{Lte: rangeBound(v)}
+}
+
+// lt: less than
+func LessThan[T McRangeValue](v T) McRange {
+	return McRange{Lt: rangeBound(v)}
+}
+
+// gte and lte: both bounds inclusive
+func Between[T McRangeValue](from, to T) McRange {
+	return McRange{Gte: rangeBound(from), Lte: rangeBound(to)}
+}
+
+// gte and lt: upper bound exclusive, useful for time windows
+func BetweenExclusive[T McRangeValue](from, to T) McRange {
+	return McRange{Gte: rangeBound(from), Lt: rangeBound(to)}
+}
+
+// Combine bounds: AtLeast(10).And(LessThan(20))
+func (r McRange) And(other McRange) McRange {
+	if other.Gte != nil {
+		r.Gte = other.Gte
+	}
+	if other.Gt != nil {
+		r.Gt = other.Gt
+	}
+	if other.Lte != nil {
+		r.Lte = other.Lte
+	}
+	if other.Lt != nil {
+		r.Lt = other.Lt
+	}
+
+	return r
+}
+
+func rangeBound[T McRangeValue](v T) interface{} {
+	switch b := any(v).(type) {
+	case time.Time:
+		return b.Unix()
+	}
+
+	return v
+}
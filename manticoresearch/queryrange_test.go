@@ -0,0 +1,30 @@
+package manticoresearch
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRangeBounds(t *testing.T) {
+	tests := []struct {
+		name string
+		v    interface{}
+		want string
+	}{
+		{"zero bound is kept", Range("price", AtLeast(0)), `{"range":{"price":{"gte":0}}}`},
+		{"time as epoch seconds", GreaterThan(time.Unix(5, 0)), `{"gt":5}`},
+		{"at most", AtMost(int64(0)), `{"lte":0}`},
+		{"less than float", LessThan(0.5), `{"lt":0.5}`},
+		{"between", Between(9.99, 19.99), `{"gte":9.99,"lte":19.99}`},
+		{"between exclusive", BetweenExclusive(uint64(0), uint64(10)), `{"gte":0,"lt":10}`},
+		{"and", AtLeast(0).And(LessThan(20)), `{"gte":0,"lt":20}`},
+		{"and overrides bound", AtLeast(1).And(AtLeast(0)), `{"gte":0}`},
+		{"range bounds option", NewMcQueryOptions().AddRangeBounds("price", AtLeast(0)), `{"bool":{"must":[{"range":{"price":{"gte":0}}}]}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertJSON(t, tt.name, tt.v, tt.want)
+		})
+	}
+}
@@ -41,3 +41,34 @@ func (qb McSortOptions) SingleFieldOrder(field string, order string) McSortOptio
 
 	return qb
 }
+
+// Sort by distance from anchor
+/*
+"sort": [{
+	"_geo_distance": {
+		"location_anchor": {"lat": 49, "lon": 15},
+		"location_source": {"lat": "attr_lat", "lon": "attr_lon"},
+		"distance_type": "adaptive",
+		"order": "asc"
+	}
+}]
+*/
+type McSortGeoDistance struct {
+	LocationAnchor McGeoLocation `json:"location_anchor"`
+	LocationSource McGeoLocation `json:"location_source"`
+	DistanceType   string        `json:"distance_type,omitempty"`
+	Order          string        `json:"order,omitempty"`
+}
+
+func (qb McSortOptions) GeoDistance(anchorLat, anchorLon float64, latAttr, lonAttr string, distanceType string, order string) McSortOptions {
+	qb.Sorts = append(qb.Sorts, map[string]interface{}{
+		"_geo_distance": McSortGeoDistance{
+			LocationAnchor: McGeoLocation{Lat: anchorLat, Lon: anchorLon},
+			LocationSource: McGeoLocation{Lat: latAttr, Lon: lonAttr},
+			DistanceType:   distanceType,
+			Order:          order,
+		},
+	})
+
+	return qb
+}
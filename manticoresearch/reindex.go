@@ -0,0 +1,306 @@
+package manticoresearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+/*
+Reindex (zero-downtime schema changes)
+
+Applications query a distributed table ("alias") instead of the real table:
+-> CREATE TABLE products type='distributed' local='products_v1'
+
+Reindex copies all documents into a new table and points the alias to it:
+1. CREATE TABLE products_v2 (...) from the destination schema
+2. page through products_v1 by id (/search, id > last ORDER BY id) and pass every document to the transform
+3. insert transformed documents into products_v2 with /bulk
+4. verify document counts: products_v2 has the copied documents, products_v1 has copied + skipped documents
+5. point alias to products_v2 (DROP + CREATE of the distributed table)
+6. drop products_v1 after the grace period (running queries finish on the old table)
+
+The alias change is not atomic: distributed tables can not be altered, the alias is dropped and created again
+(AlterDistributedTable). Queries sent between DROP and CREATE fail with "unknown table", retry them.
+If CREATE fails, the previous alias definition is restored and the source table is kept.
+Documents are rebuilt from _source: text fields declared indexed without stored have no content to copy,
+so Reindex rejects source tables having such fields.
+Writes to the source table during the copy are not replayed: pause writers or write to both tables,
+otherwise the source count check fails.
+*/
+const DefaultReindexBatchSize = 1000
+
+// Document transform: return nil doc to skip the document
+type McReindexTransform func(id uint64, doc map[string]interface{}) (map[string]interface{}, error)
+
+type ReindexOptions struct {
+	// Distributed table pointing to the active table. Empty: no alias swap
+	Alias string
+
+	// Documents per page and bulk request. Default: 1000
+	BatchSize int64
+
+	// Wait before dropping the source table
+	GracePeriod time.Duration
+
+	// Do not drop the source table
+	KeepSource bool
+}
+
+type ReindexResult struct {
+	Source      string
+	Destination string
+
+	SourceCount int64 // documents in source table after the copy, must be Copied + Skipped
+	Copied      int64
+	Skipped     int64
+
+	Swapped bool
+	Dropped bool
+}
+
+// Raw hit of /search: attributes as they are, numbers as json.Number
+type mcRawSearchResponse struct {
+	Hits struct {
+		Hits []struct {
+			ID     json.Number            `json:"_id"`
+			Source map[string]interface{} `json:"_source"`
+		} `json:"hits"`
+	} `json:"hits"`
+}
+
+func (m *ManticoreClient) Reindex(ctx context.Context, src string, dst TableSchema, transform McReindexTransform, opt ReindexOptions) (*ReindexResult, error) {
+	if m.IsReadOnly() {
+		return nil, errors.New("readonly mode active")
+	}
+
+	for _, name := range []string{src, dst.Name} {
+		if !sqlIdentifier.MatchString(name) {
+			return nil, fmt.Errorf("invalid table name: %q", name)
+		}
+	}
+
+	if src == dst.Name {
+		return nil, errors.New("source and destination tables must be different")
+	}
+
+	if opt.Alias != "" {
+		if !sqlIdentifier.MatchString(opt.Alias) {
+			return nil, fmt.Errorf("invalid alias name: %q", opt.Alias)
+		}
+
+		live, err := m.liveTables(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if t, ok := live[opt.Alias]; ok && t != TableTypeDistributed {
+			return nil, fmt.Errorf("alias %s is a %s table, not distributed", opt.Alias, t)
+		}
+	}
+
+	// documents are rebuilt from _source: text fields without stored content would be copied empty
+	schema, err := m.GetSchema(ctx, src)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, field := range schema.Fields {
+		if field.Type == FieldTypeText && !field.Stored {
+			return nil, fmt.Errorf("text field %s of %s is not stored, its content can not be copied", field.Name, src)
+		}
+	}
+
+	if opt.BatchSize <= 0 {
+		opt.BatchSize = DefaultReindexBatchSize
+	}
+
+	if _, err := m.CreateTable(ctx, dst, false); err != nil {
+		return nil, err
+	}
+
+	result := &ReindexResult{
+		Source:      src,
+		Destination: dst.Name,
+	}
+
+	if err := m.reindexCopy(ctx, src, dst.Name, transform, opt.BatchSize, result); err != nil {
+		return result, err
+	}
+
+	// verify
+	count, err := m.tableCount(ctx, dst.Name)
+	if err != nil {
+		return result, err
+	}
+
+	if count != result.Copied {
+		return result, fmt.Errorf("document count mismatch: %s has %d documents, copied %d", dst.Name, count, result.Copied)
+	}
+
+	if result.SourceCount, err = m.tableCount(ctx, src); err != nil {
+		return result, err
+	}
+
+	// documents written to the source during the copy are missing
+	if result.SourceCount != result.Copied+result.Skipped {
+		return result, fmt.Errorf("document count mismatch: %s has %d documents, copied %d and skipped %d", src, result.SourceCount, result.Copied, result.Skipped)
+	}
+
+	if opt.Alias == "" {
+		return result, nil
+	}
+
+	if _, err := m.AlterDistributedTable(ctx, *NewDistributedTable(opt.Alias).AddLocal(dst.Name)); err != nil {
+		return result, err
+	}
+	result.Swapped = true
+
+	if opt.KeepSource {
+		return result, nil
+	}
+
+	if opt.GracePeriod > 0 {
+		timer := time.NewTimer(opt.GracePeriod)
+		defer timer.Stop()
+
+		select {
+		case <-ctx.Done():
+			return result, ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	if _, err := m.RunCliContext(ctx, []byte(fmt.Sprintf("DROP TABLE IF EXISTS %s", src))); err != nil {
+		return result, err
+	}
+	result.Dropped = true
+
+	return result, nil
+}
+
+// Copy documents page by page ordered by id
+func (m *ManticoreClient) reindexCopy(ctx context.Context, src string, dst string, transform McReindexTransform, batchSize int64, result *ReindexResult) error {
+	var lastID uint64
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		builder := NewMCSearchQueryBuilder(src).
+			SetQuery(NewMcQueryOptions().AddBoolClause(McQueryMatchFilterSectionMust, Range("id", GreaterThan(lastID)))).
+			SetLimit(batchSize)
+		builder.Sort = []interface{}{map[string]string{"id": MCSortOrderASC}}
+		if batchSize > 1000 {
+			builder.SetMaxMatches(batchSize)
+		}
+
+		payload, _ := builder.MarshalBinary()
+		code, body, err := m.client.PostJSONContext(ctx, m.generateUrl([]string{MCApiRouteSearch}), payload)
+		if err != nil {
+			return err
+		}
+
+		if m.client.debug {
+			fmt.Printf("\nBody: %s - Status: %d\n", string(body), code)
+		}
+
+		// a failed page must not look like the end of the table
+		if err := reindexSearchError(code, body); err != nil {
+			return fmt.Errorf("search %s after id %d: %w", src, lastID, err)
+		}
+
+		page := mcRawSearchResponse{}
+		dec := json.NewDecoder(bytes.NewReader(body))
+		dec.UseNumber()
+		if err := dec.Decode(&page); err != nil {
+			return err
+		}
+
+		hits := page.Hits.Hits
+		if len(hits) == 0 {
+			return nil
+		}
+
+		items := make([]MCDocumentUpsertRequest, 0, len(hits))
+		for _, hit := range hits {
+			id, err := strconv.ParseUint(hit.ID.String(), 10, 64)
+			if err != nil {
+				return err
+			}
+			lastID = id
+
+			doc := hit.Source
+			if transform != nil {
+				if doc, err = transform(id, doc); err != nil {
+					return fmt.Errorf("transform document %d: %w", id, err)
+				}
+			}
+
+			if doc == nil {
+				result.Skipped++
+				continue
+			}
+
+			items = append(items, MCDocumentUpsertRequest{
+				Index: dst,
+				Id:    id,
+				Doc:   doc,
+			})
+		}
+
+		if len(items) > 0 {
+			if _, err := m.BulkInsertContext(ctx, items...); err != nil {
+				return err
+			}
+
+			result.Copied += int64(len(items))
+		}
+
+		if int64(len(hits)) < batchSize {
+			return nil
+		}
+	}
+}
+
+// Error of /search response: {"error": "..."} or {"error": {"type": "...", "reason": "..."}}
+func reindexSearchError(code int, body []byte) error {
+	errorResp := struct {
+		Error json.RawMessage `json:"error"`
+	}{}
+
+	if err := json.Unmarshal(body, &errorResp); err == nil && len(errorResp.Error) > 0 && string(errorResp.Error) != "null" {
+		msg := ""
+		if err := json.Unmarshal(errorResp.Error, &msg); err != nil {
+			msg = string(errorResp.Error)
+		}
+
+		return errors.New(msg)
+	}
+
+	if code != http.StatusOK {
+		return fmt.Errorf("unexpected status %d: %s", code, string(body))
+	}
+
+	return nil
+}
+
+func (m *ManticoreClient) tableCount(ctx context.Context, table string) (int64, error) {
+	resp, err := m.RunCliContext(ctx, []byte(fmt.Sprintf("SELECT COUNT(*) FROM %s", table)))
+	if err != nil {
+		return 0, err
+	}
+
+	rows := resp.Rows()
+	if len(rows) == 0 {
+		return 0, errors.New("empty COUNT(*) response")
+	}
+
+	return rowInt64(rows[0], "count(*)"), nil
+}
@@ -0,0 +1,33 @@
+package manticoresearch
+
+import "testing"
+
+func TestReindexSearchError(t *testing.T) {
+	tests := []struct {
+		name    string
+		code    int
+		body    string
+		wantErr string
+	}{
+		{"hits", 200, `{"took":0,"timed_out":false,"hits":{"total":0,"hits":[]}}`, ""},
+		{"string error", 200, `{"error":"unknown local table(s) 'products' in search request"}`, "unknown local table(s) 'products' in search request"},
+		{"object error", 400, `{"error":{"type":"parse_error","reason":"unknown key"},"status":400}`, `{"type":"parse_error","reason":"unknown key"}`},
+		{"null error", 200, `{"error":null,"hits":{"hits":[]}}`, ""},
+		{"status without error body", 502, `Bad Gateway`, "unexpected status 502: Bad Gateway"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := reindexSearchError(tt.code, []byte(tt.body))
+
+			got := ""
+			if err != nil {
+				got = err.Error()
+			}
+
+			if got != tt.wantErr {
+				t.Errorf("reindexSearchError() = %q, want %q", got, tt.wantErr)
+			}
+		})
+	}
+}
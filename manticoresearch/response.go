@@ -38,6 +38,8 @@ type MCPercolateResponse struct {
 	Type   string `json:"type"`
 	ID     string `json:"_id"`
 	Result string `json:"result"`
+
+	ForcedRefresh bool `json:"forced_refresh,omitempty"`
 }
 
 // Info Response
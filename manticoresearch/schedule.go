@@ -0,0 +1,224 @@
+package manticoresearch
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/*
+Schedules
+
+Cron expressions with 5 fields: minute hour day-of-month month day-of-week
+- "0 3 * * *"        every day at 03:00
+- "0-59/15 * * * *"  every 15 minutes (a step on "*" works too)
+- "30 2 * * 1-5"     weekdays at 02:30
+- "0 4 1,15 * *"     1st and 15th of month at 04:00
+
+Descriptors: @hourly, @daily (@midnight), @weekly, @monthly, @every <duration> (e.g. @every 10m)
+
+Specs that never match, like "0 0 31 4 *" (April 31st), are rejected.
+*/
+type McSchedule interface {
+	// Next activation time after t, zero if none
+	Next(t time.Time) time.Time
+}
+
+type mcEverySchedule struct {
+	interval time.Duration
+}
+
+type mcCronSchedule struct {
+	minute, hour, dom, month, dow uint64
+
+	// unrestricted day fields: dom and dow are ORed only when both are restricted
+	domStar, dowStar bool
+}
+
+func ParseSchedule(spec string) (McSchedule, error) {
+	spec = strings.TrimSpace(spec)
+
+	switch spec {
+	case "@hourly":
+		spec = "0 * * * *"
+	case "@daily", "@midnight":
+		spec = "0 0 * * *"
+	case "@weekly":
+		spec = "0 0 * * 0"
+	case "@monthly":
+		spec = "0 0 1 * *"
+	}
+
+	if strings.HasPrefix(spec, "@every ") {
+		interval, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(spec, "@every ")))
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule %q: %w", spec, err)
+		}
+
+		if interval < time.Second {
+			return nil, fmt.Errorf("invalid schedule %q: interval must be at least 1s", spec)
+		}
+
+		return mcEverySchedule{interval: interval}, nil
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid schedule %q: expected 5 fields", spec)
+	}
+
+	s := mcCronSchedule{
+		domStar: fields[2] == "*",
+		dowStar: fields[4] == "*",
+	}
+
+	for _, f := range []struct {
+		target   *uint64
+		spec     string
+		min, max int
+	}{
+		{&s.minute, fields[0], 0, 59},
+		{&s.hour, fields[1], 0, 23},
+		{&s.dom, fields[2], 1, 31},
+		{&s.month, fields[3], 1, 12},
+		{&s.dow, fields[4], 0, 7},
+	} {
+		bits, err := parseCronField(f.spec, f.min, f.max)
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule %q: %w", spec, err)
+		}
+
+		*f.target = bits
+	}
+
+	// 7 is sunday too
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+
+	if !s.possible() {
+		return nil, fmt.Errorf("invalid schedule %q: day of month never exists in given months", spec)
+	}
+
+	return s, nil
+}
+
+func (s mcEverySchedule) Next(t time.Time) time.Time {
+	return t.Add(s.interval)
+}
+
+func (s mcCronSchedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+
+	// February 29th can be 8 years apart: 2096 -> 2104
+	limit := t.AddDate(9, 0, 0)
+
+	for t.Before(limit) {
+		y, mo, d := t.Date()
+
+		if s.month&(1<<uint(mo)) == 0 {
+			t = time.Date(y, mo+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if !s.dayMatches(t) {
+			t = time.Date(y, mo, d+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(y, mo, d, t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
+
+// Some day of month exists in some month, only checked when day of week is unrestricted
+func (s mcCronSchedule) possible() bool {
+	if s.domStar || !s.dowStar {
+		return true
+	}
+
+	daysInMonth := [13]int{0, 31, 29, 31, 30, 31, 30, 31, 31, 30, 31, 30, 31}
+	for month := 1; month <= 12; month++ {
+		if s.month&(1<<uint(month)) == 0 {
+			continue
+		}
+
+		for day := 1; day <= daysInMonth[month]; day++ {
+			if s.dom&(1<<uint(day)) != 0 {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (s mcCronSchedule) dayMatches(t time.Time) bool {
+	dom := s.dom&(1<<uint(t.Day())) != 0
+	dow := s.dow&(1<<uint(t.Weekday())) != 0
+
+	if s.domStar || s.dowStar {
+		return dom && dow
+	}
+
+	return dom || dow
+}
+
+// Field bits: "*", "5", "1-5", "*/15", "0-30/10", "1,15"
+func parseCronField(spec string, min int, max int) (uint64, error) {
+	var bits uint64
+
+	for _, item := range strings.Split(spec, ",") {
+		rangeSpec, stepSpec, hasStep := strings.Cut(item, "/")
+
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepSpec); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step %q", item)
+			}
+		}
+
+		from, to := min, max
+		if rangeSpec != "*" {
+			fromSpec, toSpec, isRange := strings.Cut(rangeSpec, "-")
+
+			var err error
+			if from, err = strconv.Atoi(fromSpec); err != nil {
+				return 0, fmt.Errorf("invalid value %q", item)
+			}
+
+			to = from
+			if isRange {
+				if to, err = strconv.Atoi(toSpec); err != nil {
+					return 0, fmt.Errorf("invalid value %q", item)
+				}
+			} else if hasStep {
+				// "5/15": from 5 to max
+				to = max
+			}
+		}
+
+		if from < min || to > max || from > to {
+			return 0, fmt.Errorf("value %q out of range %d-%d", item, min, max)
+		}
+
+		for i := from; i <= to; i += step {
+			bits |= 1 << uint(i)
+		}
+	}
+
+	return bits, nil
+}
@@ -0,0 +1,100 @@
+package manticoresearch
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSchedule(t *testing.T) {
+	tests := []struct {
+		spec    string
+		wantErr bool
+	}{
+		{"0 3 * * *", false},
+		{"*/15 * * * *", false},
+		{"0-59/15 * * * *", false},
+		{"30 2 * * 1-5", false},
+		{"0 4 1,15 * *", false},
+		{"0 0 * * 7", false},
+		{"0 0 29 2 *", false},
+		{"0 0 31 4 1", false},
+		{"@hourly", false},
+		{"@daily", false},
+		{"@midnight", false},
+		{"@weekly", false},
+		{"@monthly", false},
+		{"@every 10m", false},
+		{" @every 1h30m ", false},
+		{"@every 500ms", true},
+		{"@every soon", true},
+		{"@yearly", true},
+		{"", true},
+		{"0 3 * *", true},
+		{"60 * * * *", true},
+		{"0 24 * * *", true},
+		{"0 0 0 * *", true},
+		{"0 0 * 13 *", true},
+		{"0 0 * * 8", true},
+		{"5-1 * * * *", true},
+		{"*/0 * * * *", true},
+		{"a * * * *", true},
+		{"0 0 31 4 *", true},
+		{"0 0 30,31 2 *", true},
+		{"0 0 31 2,4,6,9,11 *", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			_, err := ParseSchedule(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseSchedule(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestScheduleNext(t *testing.T) {
+	at := func(s string) time.Time {
+		tm, err := time.Parse("2006-01-02 15:04:05", s)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return tm
+	}
+
+	tests := []struct {
+		name string
+		spec string
+		from string
+		want string
+	}{
+		{"daily later today", "0 3 * * *", "2024-01-01 02:59:30", "2024-01-01 03:00:00"},
+		{"daily next day", "0 3 * * *", "2024-01-01 03:00:00", "2024-01-02 03:00:00"},
+		{"step minutes", "*/15 * * * *", "2024-01-01 10:16:00", "2024-01-01 10:30:00"},
+		{"step hour rollover", "0-59/15 * * * *", "2024-01-01 10:50:00", "2024-01-01 11:00:00"},
+		{"weekdays skip weekend", "30 2 * * 1-5", "2024-01-05 03:00:00", "2024-01-08 02:30:00"},
+		{"sunday as 7", "0 0 * * 7", "2024-01-01 00:00:00", "2024-01-07 00:00:00"},
+		{"day of month list", "0 4 1,15 * *", "2024-01-02 00:00:00", "2024-01-15 04:00:00"},
+		{"dom or dow when both restricted", "0 0 13 * 5", "2024-01-01 00:00:00", "2024-01-05 00:00:00"},
+		{"month rollover into next year", "0 0 1 1 *", "2024-06-01 00:00:00", "2025-01-01 00:00:00"},
+		{"february 29th", "0 0 29 2 *", "2024-03-01 00:00:00", "2028-02-29 00:00:00"},
+		{"february 29th across 2100", "0 0 29 2 *", "2096-03-01 00:00:00", "2104-02-29 00:00:00"},
+		{"hourly", "@hourly", "2024-01-01 10:00:00", "2024-01-01 11:00:00"},
+		{"monthly", "@monthly", "2024-01-31 12:00:00", "2024-02-01 00:00:00"},
+		{"every interval", "@every 90m", "2024-01-01 10:00:30", "2024-01-01 11:30:30"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := ParseSchedule(tt.spec)
+			if err != nil {
+				t.Fatalf("ParseSchedule(%q) error = %v", tt.spec, err)
+			}
+
+			if got := schedule.Next(at(tt.from)); !got.Equal(at(tt.want)) {
+				t.Errorf("Next(%s) = %s, want %s", tt.from, got, tt.want)
+			}
+		})
+	}
+}
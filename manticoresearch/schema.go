@@ -0,0 +1,341 @@
+package manticoresearch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+/*
+Table Schema
+Via: https://manual.manticoresearch.com/Creating_a_table/Local_tables/Real-time_table
+
+	CREATE TABLE IF NOT EXISTS products (
+		title text,
+		description text indexed,
+		brand string attribute indexed,
+		price float,
+		tags multi,
+		meta json,
+		image_vector float_vector knn_type='hnsw' knn_dims='4' hnsw_similarity='l2'
+	) morphology='stem_en' min_infix_len='2' engine='columnar'
+
+The "id" (bigint) attribute is added by the server, do not declare it.
+*/
+const (
+	TableTypeRT          = "rt"
+	TableTypePercolate   = "pq"
+	TableTypeDistributed = "distributed"
+)
+
+// Field and attribute types
+const (
+	FieldTypeText        = "text"
+	FieldTypeInt         = "int" // uint
+	FieldTypeBigint      = "bigint"
+	FieldTypeFloat       = "float"
+	FieldTypeBool        = "bool"
+	FieldTypeTimestamp   = "timestamp"
+	FieldTypeString      = "string"
+	FieldTypeJson        = "json"
+	FieldTypeMulti       = "multi"
+	FieldTypeMulti64     = "multi64"
+	FieldTypeFloatVector = "float_vector"
+)
+
+// Attribute storage engines
+const (
+	TableEngineColumnar = "columnar"
+	TableEngineRowwise  = "rowwise"
+)
+
+// Common table settings
+const (
+	TableSettingType        = "type"
+	TableSettingEngine      = "engine"
+	TableSettingMorphology  = "morphology"
+	TableSettingMinInfixLen = "min_infix_len"
+	TableSettingMinPrefix   = "min_prefix_len"
+	TableSettingRtMemLimit  = "rt_mem_limit"
+	TableSettingStopwords   = "stopwords"
+	TableSettingCharset     = "charset_table"
+	TableSettingHtmlStrip   = "html_strip"
+	TableSettingIndexExact  = "index_exact_words"
+	TableSettingDict        = "dict"
+)
+
+var sqlIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+type TableField struct {
+	Name string
+	Type string
+
+	// text: full-text indexed, string: also indexed as full-text field (string attribute indexed)
+	Indexed bool
+	// text: original value stored in docstore
+	Stored bool
+
+	// Attribute storage engine: columnar or rowwise. Empty: table engine
+	Engine string
+
+	// float_vector knn settings
+	KnnType        string // hnsw
+	KnnDims        int
+	HnswSimilarity string // l2, ip, cosine
+}
+
+type TableSchema struct {
+	Name   string
+	Fields []TableField
+
+	// Table settings: morphology, min_infix_len, engine etc.
+	Settings map[string]string
+}
+
+func NewTableSchema(name string) *TableSchema {
+	return &TableSchema{
+		Name:     name,
+		Settings: map[string]string{},
+	}
+}
+
+// Full-text field: indexed and/or stored
+func (s *TableSchema) AddText(name string, indexed bool, stored bool) *TableSchema {
+	s.Fields = append(s.Fields, TableField{
+		Name:    name,
+		Type:    FieldTypeText,
+		Indexed: indexed,
+		Stored:  stored,
+	})
+
+	return s
+}
+
+// Attribute: int, bigint, float, bool, timestamp, string, json, multi, multi64
+func (s *TableSchema) AddAttribute(name string, fieldType string) *TableSchema {
+	s.Fields = append(s.Fields, TableField{
+		Name: name,
+		Type: fieldType,
+	})
+
+	return s
+}
+
+// String attribute, indexed: also searchable as full-text field
+func (s *TableSchema) AddString(name string, indexed bool) *TableSchema {
+	s.Fields = append(s.Fields, TableField{
+		Name:    name,
+		Type:    FieldTypeString,
+		Indexed: indexed,
+	})
+
+	return s
+}
+
+// Vector attribute with hnsw index: similarity l2, ip or cosine
+func (s *TableSchema) AddFloatVector(name string, dims int, similarity string) *TableSchema {
+	s.Fields = append(s.Fields, TableField{
+		Name:           name,
+		Type:           FieldTypeFloatVector,
+		KnnType:        "hnsw",
+		KnnDims:        dims,
+		HnswSimilarity: similarity,
+	})
+
+	return s
+}
+
+func (s *TableSchema) AddField(field TableField) *TableSchema {
+	s.Fields = append(s.Fields, field)
+
+	return s
+}
+
+func (s *TableSchema) AddSetting(key string, value string) *TableSchema {
+	if s.Settings == nil {
+		s.Settings = map[string]string{}
+	}
+
+	s.Settings[key] = value
+
+	return s
+}
+
+func (s *TableSchema) SetType(tableType string) *TableSchema {
+	if tableType == "" || tableType == TableTypeRT {
+		delete(s.Settings, TableSettingType)
+		return s
+	}
+
+	return s.AddSetting(TableSettingType, tableType)
+}
+
+func (s *TableSchema) SetEngine(engine string) *TableSchema {
+	return s.AddSetting(TableSettingEngine, engine)
+}
+
+func (s *TableSchema) SetMorphology(morphology ...string) *TableSchema {
+	return s.AddSetting(TableSettingMorphology, strings.Join(morphology, ","))
+}
+
+func (s *TableSchema) SetMinInfixLen(length int) *TableSchema {
+	return s.AddSetting(TableSettingMinInfixLen, fmt.Sprint(length))
+}
+
+func (s *TableSchema) SetRtMemLimit(limit string) *TableSchema {
+	return s.AddSetting(TableSettingRtMemLimit, limit)
+}
+
+// Table type from settings. Default: rt
+func (s TableSchema) Type() string {
+	if t, ok := s.Settings[TableSettingType]; ok && t != "" {
+		return t
+	}
+
+	return TableTypeRT
+}
+
+func (s TableSchema) Field(name string) (TableField, bool) {
+	for _, field := range s.Fields {
+		if strings.EqualFold(field.Name, name) {
+			return field, true
+		}
+	}
+
+	return TableField{}, false
+}
+
+// Column definition: "title text indexed", "price float engine='columnar'"
+func (f TableField) Definition() (string, error) {
+	if !sqlIdentifier.MatchString(f.Name) {
+		return "", fmt.Errorf("invalid field name: %q", f.Name)
+	}
+
+	if strings.EqualFold(f.Name, "id") {
+		return "", errors.New("id attribute is added by the server")
+	}
+
+	def := []string{f.Name, f.Type}
+
+	switch f.Type {
+	case FieldTypeText:
+		if !f.Indexed && !f.Stored {
+			return "", fmt.Errorf("text field %s must be indexed or stored", f.Name)
+		}
+
+		if f.Indexed && !f.Stored {
+			def = append(def, "indexed")
+		} else if f.Stored && !f.Indexed {
+			def = append(def, "stored")
+		}
+	case FieldTypeString:
+		if f.Indexed {
+			def = append(def, "attribute", "indexed")
+		}
+	case FieldTypeFloatVector:
+		if f.KnnDims <= 0 {
+			return "", fmt.Errorf("float_vector %s requires knn_dims", f.Name)
+		}
+
+		knnType := f.KnnType
+		if knnType == "" {
+			knnType = "hnsw"
+		}
+
+		similarity := f.HnswSimilarity
+		if similarity == "" {
+			similarity = "l2"
+		}
+
+		def = append(def,
+			fmt.Sprintf("knn_type=%s", sqlQuote(knnType)),
+			fmt.Sprintf("knn_dims=%s", sqlQuote(fmt.Sprint(f.KnnDims))),
+			fmt.Sprintf("hnsw_similarity=%s", sqlQuote(similarity)),
+		)
+	case FieldTypeInt, FieldTypeBigint, FieldTypeFloat, FieldTypeBool, FieldTypeTimestamp,
+		FieldTypeJson, FieldTypeMulti, FieldTypeMulti64:
+	default:
+		return "", fmt.Errorf("unknown type %q of field %s", f.Type, f.Name)
+	}
+
+	if f.Engine != "" && f.Type != FieldTypeText {
+		def = append(def, fmt.Sprintf("engine=%s", sqlQuote(f.Engine)))
+	}
+
+	return strings.Join(def, " "), nil
+}
+
+// Table settings in key order: morphology='stem_en' min_infix_len='2'
+func (s TableSchema) settingsSQL() (string, error) {
+	keys := make([]string, 0, len(s.Settings))
+	for key := range s.Settings {
+		if !sqlIdentifier.MatchString(key) {
+			return "", fmt.Errorf("invalid setting name: %q", key)
+		}
+
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	settings := make([]string, 0, len(keys))
+	for _, key := range keys {
+		settings = append(settings, fmt.Sprintf("%s=%s", key, sqlQuote(s.Settings[key])))
+	}
+
+	return strings.Join(settings, " "), nil
+}
+
+// CREATE TABLE statement
+func (s TableSchema) CreateTableSQL(ifNotExists bool) (string, error) {
+	if !sqlIdentifier.MatchString(s.Name) {
+		return "", fmt.Errorf("invalid table name: %q", s.Name)
+	}
+
+	cmd := []string{"CREATE TABLE"}
+	if ifNotExists {
+		cmd = append(cmd, "IF NOT EXISTS")
+	}
+	cmd = append(cmd, s.Name)
+
+	defs := make([]string, 0, len(s.Fields))
+	for _, field := range s.Fields {
+		def, err := field.Definition()
+		if err != nil {
+			return "", err
+		}
+
+		defs = append(defs, def)
+	}
+
+	// percolate tables can be created without fields
+	if len(defs) > 0 {
+		cmd[len(cmd)-1] = fmt.Sprintf("%s(%s)", s.Name, strings.Join(defs, ", "))
+	}
+
+	settings, err := s.settingsSQL()
+	if err != nil {
+		return "", err
+	}
+
+	if settings != "" {
+		cmd = append(cmd, settings)
+	}
+
+	return strings.Join(cmd, " "), nil
+}
+
+func (m *ManticoreClient) CreateTable(ctx context.Context, schema TableSchema, ifNotExists bool) (resp *MCDocumentMainResponse, err error) {
+	if m.IsReadOnly() {
+		return nil, errors.New("readonly mode active")
+	}
+
+	cmd, err := schema.CreateTableSQL(ifNotExists)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.RunCliContext(ctx, []byte(cmd))
+}
@@ -0,0 +1,105 @@
+package manticoresearch
+
+import "testing"
+
+func TestTableFieldDefinition(t *testing.T) {
+	tests := []struct {
+		name    string
+		field   TableField
+		want    string
+		wantErr bool
+	}{
+		{"text indexed and stored", TableField{Name: "title", Type: FieldTypeText, Indexed: true, Stored: true}, "title text", false},
+		{"text indexed only", TableField{Name: "body", Type: FieldTypeText, Indexed: true}, "body text indexed", false},
+		{"text stored only", TableField{Name: "raw", Type: FieldTypeText, Stored: true}, "raw text stored", false},
+		{"text neither indexed nor stored", TableField{Name: "title", Type: FieldTypeText}, "", true},
+		{"string attribute", TableField{Name: "brand", Type: FieldTypeString}, "brand string", false},
+		{"string attribute indexed", TableField{Name: "brand", Type: FieldTypeString, Indexed: true}, "brand string attribute indexed", false},
+		{"columnar float", TableField{Name: "price", Type: FieldTypeFloat, Engine: TableEngineColumnar}, "price float engine='columnar'", false},
+		{"text ignores engine", TableField{Name: "title", Type: FieldTypeText, Indexed: true, Stored: true, Engine: TableEngineColumnar}, "title text", false},
+		{"float vector defaults", TableField{Name: "vec", Type: FieldTypeFloatVector, KnnDims: 4}, "vec float_vector knn_type='hnsw' knn_dims='4' hnsw_similarity='l2'", false},
+		{"float vector cosine", TableField{Name: "vec", Type: FieldTypeFloatVector, KnnDims: 8, HnswSimilarity: "cosine"}, "vec float_vector knn_type='hnsw' knn_dims='8' hnsw_similarity='cosine'", false},
+		{"float vector without dims", TableField{Name: "vec", Type: FieldTypeFloatVector}, "", true},
+		{"id is reserved", TableField{Name: "id", Type: FieldTypeBigint}, "", true},
+		{"invalid name", TableField{Name: "price-1", Type: FieldTypeFloat}, "", true},
+		{"unknown type", TableField{Name: "price", Type: "decimal"}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.field.Definition()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Definition() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("Definition() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTableSchemaCreateTableSQL(t *testing.T) {
+	tests := []struct {
+		name        string
+		schema      *TableSchema
+		ifNotExists bool
+		want        string
+		wantErr     bool
+	}{
+		{
+			name:   "fields and settings in key order",
+			schema: NewTableSchema("products").AddText("title", true, true).AddAttribute("price", FieldTypeFloat).SetMinInfixLen(2).SetMorphology("stem_en"),
+			want:   "CREATE TABLE products(title text, price float) min_infix_len='2' morphology='stem_en'",
+		},
+		{
+			name:        "if not exists",
+			schema:      NewTableSchema("products").AddString("brand", true),
+			ifNotExists: true,
+			want:        "CREATE TABLE IF NOT EXISTS products(brand string attribute indexed)",
+		},
+		{
+			name:   "percolate table without fields",
+			schema: NewTableSchema("alerts").SetType(TableTypePercolate),
+			want:   "CREATE TABLE alerts type='pq'",
+		},
+		{
+			name:   "rt type is not written",
+			schema: NewTableSchema("products").AddAttribute("price", FieldTypeFloat).SetType(TableTypeRT),
+			want:   "CREATE TABLE products(price float)",
+		},
+		{
+			name:   "setting value is quoted",
+			schema: NewTableSchema("products").AddAttribute("price", FieldTypeFloat).AddSetting(TableSettingStopwords, "it's"),
+			want:   "CREATE TABLE products(price float) stopwords='it\\'s'",
+		},
+		{
+			name:    "invalid table name",
+			schema:  NewTableSchema("products;DROP"),
+			wantErr: true,
+		},
+		{
+			name:    "invalid setting name",
+			schema:  NewTableSchema("products").AddSetting("min infix", "2"),
+			wantErr: true,
+		},
+		{
+			name:    "invalid field",
+			schema:  NewTableSchema("products").AddAttribute("id", FieldTypeBigint),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.schema.CreateTableSQL(tt.ifNotExists)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CreateTableSQL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("CreateTableSQL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
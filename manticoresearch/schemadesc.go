@@ -0,0 +1,215 @@
+package manticoresearch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+/*
+Live Table Schema
+
+Fields, types and properties are parsed from DESC:
+-> DESC products
+
++-------+--------+----------------+
+| Field | Type   | Properties     |
++-------+--------+----------------+
+| id    | bigint |                |
+| title | text   | indexed stored |
+| price | float  |                |
+| tags  | mva    |                |
++-------+--------+----------------+
+
+Table settings and column options (engine, knn) are parsed from SHOW CREATE TABLE:
+-> SHOW CREATE TABLE products
+
+CREATE TABLE products (
+id bigint,
+title text,
+price float engine='columnar',
+tags multi
+) morphology='stem_en' min_infix_len='2'
+
+Compare the result with the expected schema at startup to catch drifts.
+*/
+var sqlSettingPattern = regexp.MustCompile(`([A-Za-z_][A-Za-z0-9_]*)\s*=\s*'((?:[^'\\]|\\.)*)'`)
+
+func (m *ManticoreClient) GetSchema(ctx context.Context, tableName string) (*TableSchema, error) {
+	if !sqlIdentifier.MatchString(tableName) {
+		return nil, fmt.Errorf("invalid table name: %q", tableName)
+	}
+
+	desc, err := m.RunCliContext(ctx, []byte(fmt.Sprintf("DESC %s", tableName)))
+	if err != nil {
+		return nil, err
+	}
+
+	schema := NewTableSchema(tableName)
+	for _, row := range desc.Rows() {
+		name := rowString(row, "Field")
+		if name == "" || strings.EqualFold(name, "id") {
+			continue
+		}
+
+		field := TableField{
+			Name: name,
+			Type: normalizeFieldType(rowString(row, "Type")),
+		}
+
+		for _, prop := range strings.Fields(rowString(row, "Properties")) {
+			switch prop {
+			case "indexed":
+				field.Indexed = true
+			case "stored":
+				field.Stored = true
+			case TableEngineColumnar:
+				field.Engine = TableEngineColumnar
+			}
+		}
+
+		// string attribute indexed: full-text field and string attribute with the same name
+		if prev, ok := schema.Field(name); ok {
+			for i := range schema.Fields {
+				if schema.Fields[i].Name == prev.Name {
+					schema.Fields[i].Type = FieldTypeString
+					schema.Fields[i].Indexed = true
+					schema.Fields[i].Stored = false
+				}
+			}
+
+			continue
+		}
+
+		schema.AddField(field)
+	}
+
+	create, err := m.RunCliContext(ctx, []byte(fmt.Sprintf("SHOW CREATE TABLE %s", tableName)))
+	if err != nil {
+		return nil, err
+	}
+
+	rows := create.Rows()
+	if len(rows) == 0 {
+		return nil, errors.New("empty SHOW CREATE TABLE response")
+	}
+
+	columns, settings, err := parseCreateTable(rowString(rows[0], "Create Table"))
+	if err != nil {
+		return nil, err
+	}
+
+	schema.Settings = settings
+	for i, field := range schema.Fields {
+		options, ok := columns[strings.ToLower(field.Name)]
+		if !ok {
+			continue
+		}
+
+		if engine, ok := options["engine"]; ok {
+			schema.Fields[i].Engine = engine
+		}
+
+		if field.Type == FieldTypeFloatVector {
+			schema.Fields[i].KnnType = strings.ToLower(options["knn_type"])
+			schema.Fields[i].KnnDims, _ = strconv.Atoi(options["knn_dims"])
+			schema.Fields[i].HnswSimilarity = strings.ToLower(options["hnsw_similarity"])
+		}
+	}
+
+	return schema, nil
+}
+
+// DESC and SHOW CREATE TABLE type names -> CREATE TABLE type names
+func normalizeFieldType(t string) string {
+	switch t = strings.ToLower(strings.TrimSpace(t)); t {
+	case "uint", "integer":
+		return FieldTypeInt
+	case "mva":
+		return FieldTypeMulti
+	case "mva64":
+		return FieldTypeMulti64
+	}
+
+	return t
+}
+
+// Column options (key='value' pairs per column) and table settings of CREATE TABLE statement
+func parseCreateTable(stmt string) (columns map[string]map[string]string, settings map[string]string, err error) {
+	columns = map[string]map[string]string{}
+	settings = map[string]string{}
+
+	start := strings.Index(stmt, "(")
+	if start < 0 {
+		// table without columns: CREATE TABLE pq type='pq'
+		return columns, parseSettings(stmt), nil
+	}
+
+	// find closing paren of column list, skipping quoted values
+	depth, end, quoted := 0, -1, false
+	for i := start; i < len(stmt) && end < 0; i++ {
+		switch c := stmt[i]; {
+		case quoted && c == '\\':
+			i++
+		case c == '\'':
+			quoted = !quoted
+		case quoted:
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth == 0 {
+				end = i
+			}
+		}
+	}
+
+	if end < 0 {
+		return nil, nil, errors.New("invalid CREATE TABLE statement")
+	}
+
+	for _, def := range splitColumns(stmt[start+1 : end]) {
+		parts := strings.Fields(def)
+		if len(parts) == 0 {
+			continue
+		}
+
+		columns[strings.ToLower(strings.Trim(parts[0], "`"))] = parseSettings(def)
+	}
+
+	return columns, parseSettings(stmt[end+1:]), nil
+}
+
+// split column definitions by top level commas
+func splitColumns(s string) []string {
+	defs := []string{}
+	quoted, last := false, 0
+
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case quoted && c == '\\':
+			i++
+		case c == '\'':
+			quoted = !quoted
+		case !quoted && c == ',':
+			defs = append(defs, strings.TrimSpace(s[last:i]))
+			last = i + 1
+		}
+	}
+
+	return append(defs, strings.TrimSpace(s[last:]))
+}
+
+func parseSettings(s string) map[string]string {
+	settings := map[string]string{}
+	unescape := strings.NewReplacer(`\'`, `'`, `\\`, `\`)
+
+	for _, match := range sqlSettingPattern.FindAllStringSubmatch(s, -1) {
+		settings[strings.ToLower(match[1])] = unescape.Replace(match[2])
+	}
+
+	return settings
+}
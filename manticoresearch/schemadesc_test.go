@@ -0,0 +1,121 @@
+package manticoresearch
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseCreateTable(t *testing.T) {
+	tests := []struct {
+		name         string
+		stmt         string
+		wantColumns  map[string]map[string]string
+		wantSettings map[string]string
+		wantErr      bool
+	}{
+		{
+			name: "columns and settings",
+			stmt: "CREATE TABLE products (\nid bigint,\ntitle text,\nprice float engine='columnar',\ntags multi\n) morphology='stem_en' min_infix_len='2'",
+			wantColumns: map[string]map[string]string{
+				"id":    {},
+				"title": {},
+				"price": {"engine": "columnar"},
+				"tags":  {},
+			},
+			wantSettings: map[string]string{"morphology": "stem_en", "min_infix_len": "2"},
+		},
+		{
+			name: "float vector options",
+			stmt: "CREATE TABLE items (vec float_vector knn_type='hnsw' knn_dims='4' hnsw_similarity='COSINE')",
+			wantColumns: map[string]map[string]string{
+				"vec": {"knn_type": "hnsw", "knn_dims": "4", "hnsw_similarity": "COSINE"},
+			},
+			wantSettings: map[string]string{},
+		},
+		{
+			name:         "percolate table without columns",
+			stmt:         "CREATE TABLE alerts type='pq'",
+			wantColumns:  map[string]map[string]string{},
+			wantSettings: map[string]string{"type": "pq"},
+		},
+		{
+			name: "quoted parens and commas in settings",
+			stmt: "CREATE TABLE t (title text, `Tag` string) charset_table='non_cjk, U+00E9' regexp_filter='(\\d+)\\'s'",
+			wantColumns: map[string]map[string]string{
+				"title": {},
+				"tag":   {},
+			},
+			wantSettings: map[string]string{"charset_table": "non_cjk, U+00E9", "regexp_filter": "(\\d+)'s"},
+		},
+		{
+			name:    "unclosed column list",
+			stmt:    "CREATE TABLE t (title text",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			columns, settings, err := parseCreateTable(tt.stmt)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseCreateTable() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if !reflect.DeepEqual(columns, tt.wantColumns) {
+				t.Errorf("parseCreateTable() columns = %v, want %v", columns, tt.wantColumns)
+			}
+
+			if !reflect.DeepEqual(settings, tt.wantSettings) {
+				t.Errorf("parseCreateTable() settings = %v, want %v", settings, tt.wantSettings)
+			}
+		})
+	}
+}
+
+func TestSplitColumns(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want []string
+	}{
+		{"single", "title text", []string{"title text"}},
+		{"trimmed", " title text ,\n price float ", []string{"title text", "price float"}},
+		{"comma in quotes", "a text, b string engine='x,y', c int", []string{"a text", "b string engine='x,y'", "c int"}},
+		{"escaped quote", `a string engine='it\'s, ok', b int`, []string{`a string engine='it\'s, ok'`, "b int"}},
+		{"empty", "", []string{""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := splitColumns(tt.s); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("splitColumns() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeFieldType(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"uint", FieldTypeInt},
+		{"Integer", FieldTypeInt},
+		{"mva", FieldTypeMulti},
+		{"mva64", FieldTypeMulti64},
+		{" TEXT ", FieldTypeText},
+		{"float_vector", FieldTypeFloatVector},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := normalizeFieldType(tt.in); got != tt.want {
+				t.Errorf("normalizeFieldType(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,309 @@
+package manticoresearch
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/*
+Schema From Struct Tags
+
+Table schema and insert documents are generated from the same annotated struct:
+
+	type Product struct {
+		ID        uint64            `mc:"id"`
+		Title     string            `mc:"title,text"`             // indexed and stored
+		Body      string            `mc:"body,text,indexed"`      // indexed only
+		Raw       string            `mc:"raw,text,stored_only"`   // stored only, not searchable
+		Brand     string            `mc:"brand,string,indexed"`   // string attribute, also full-text indexed
+		Price     float64           `mc:"price"`                  // float
+		Tags      []uint64          `mc:"tags"`                   // multi64
+		Meta      map[string]string `mc:"meta"`                   // json
+		CreatedAt time.Time         `mc:"created_at"`             // timestamp (epoch seconds)
+		Vector    []float32         `mc:"vector,float_vector,dims=4,similarity=cosine"`
+	}
+
+Tag format: "name[,type][,options...]". Options mirror CREATE TABLE keywords:
+- text: indexed (indexed only), stored_only (stored only), stored or none (indexed and stored)
+- string: indexed (string attribute indexed)
+- columnar, rowwise: attribute engine
+- float_vector: dims=N, similarity=l2|ip|cosine
+
+Type is derived from the Go type when omitted:
+string -> text, bool -> bool, int8..int32/uint8..uint32 -> int, int/int64/uint/uint64 -> bigint,
+float32/float64 -> float, time.Time -> timestamp, []uint32/[]int32 -> multi, []uint64/[]int64 -> multi64,
+[]float32 -> float_vector, map/struct -> json
+
+Fields without mc tag or with mc:"-" are ignored. The field tagged mc:"id" is used as document id.
+*/
+const McTagName = "mc"
+
+var timeType = reflect.TypeOf(time.Time{})
+
+type mcTagField struct {
+	index []int
+	field TableField
+	id    bool
+}
+
+// Table schema from mc tags of struct
+func SchemaFromStruct(tableName string, v interface{}) (*TableSchema, error) {
+	fields, err := mcTagFields(reflect.TypeOf(v))
+	if err != nil {
+		return nil, err
+	}
+
+	schema := NewTableSchema(tableName)
+	for _, f := range fields {
+		if !f.id {
+			schema.AddField(f.field)
+		}
+	}
+
+	return schema, nil
+}
+
+// Insert document from mc tags of struct: time.Time as epoch seconds, id from mc:"id" field
+func EncodeDocument(v interface{}) (id uint64, doc map[string]interface{}, err error) {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return 0, nil, errors.New("document must be a struct")
+	}
+
+	fields, err := mcTagFields(rv.Type())
+	if err != nil {
+		return 0, nil, err
+	}
+
+	doc = map[string]interface{}{}
+	for _, f := range fields {
+		fv, err := rv.FieldByIndexErr(f.index)
+		if err != nil {
+			// nil embedded struct pointer
+			continue
+		}
+
+		if f.id {
+			switch fv.Kind() {
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				id = uint64(fv.Int())
+			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+				id = fv.Uint()
+			default:
+				return 0, nil, errors.New("id field must be an integer")
+			}
+
+			continue
+		}
+
+		doc[f.field.Name] = encodeDocumentValue(fv)
+	}
+
+	return id, doc, nil
+}
+
+// Upsert request from mc tags of struct
+func NewMCDocumentUpsertRequestFromStruct(index string, v interface{}) (MCDocumentUpsertRequest, error) {
+	id, doc, err := EncodeDocument(v)
+	if err != nil {
+		return MCDocumentUpsertRequest{}, err
+	}
+
+	return MCDocumentUpsertRequest{
+		Index: index,
+		Id:    id,
+		Doc:   doc,
+	}, nil
+}
+
+func encodeDocumentValue(v reflect.Value) interface{} {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+
+		v = v.Elem()
+	}
+
+	if v.Type() == timeType {
+		t := v.Interface().(time.Time)
+		if t.IsZero() {
+			return 0
+		}
+
+		return t.Unix()
+	}
+
+	return v.Interface()
+}
+
+func mcTagFields(t reflect.Type) ([]mcTagField, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct {
+		return nil, errors.New("schema source must be a struct")
+	}
+
+	fields := []mcTagField{}
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+
+		tag, ok := sf.Tag.Lookup(McTagName)
+		if tag == "-" {
+			continue
+		}
+
+		// embedded struct without tag: flatten fields
+		if !ok {
+			ft := sf.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+
+			if sf.Anonymous && ft.Kind() == reflect.Struct {
+				embedded, err := mcTagFields(ft)
+				if err != nil {
+					return nil, err
+				}
+
+				for _, e := range embedded {
+					e.index = append([]int{i}, e.index...)
+					fields = append(fields, e)
+				}
+			}
+
+			continue
+		}
+
+		if !sf.IsExported() {
+			continue
+		}
+
+		f, err := parseMcTag(tag, sf)
+		if err != nil {
+			return nil, err
+		}
+
+		f.index = []int{i}
+		fields = append(fields, f)
+	}
+
+	return fields, nil
+}
+
+func parseMcTag(tag string, sf reflect.StructField) (mcTagField, error) {
+	parts := strings.Split(tag, ",")
+
+	name := strings.TrimSpace(parts[0])
+	if name == "" {
+		name = strings.ToLower(sf.Name)
+	}
+
+	if strings.EqualFold(name, "id") {
+		return mcTagField{id: true, field: TableField{Name: name, Type: FieldTypeBigint}}, nil
+	}
+
+	field := TableField{Name: name}
+	options := parts[1:]
+	if len(options) > 0 && isFieldType(strings.TrimSpace(options[0])) {
+		field.Type = strings.TrimSpace(options[0])
+		options = options[1:]
+	} else {
+		field.Type = fieldTypeOf(sf.Type)
+		if field.Type == "" {
+			return mcTagField{}, fmt.Errorf("can not map type %s of field %s", sf.Type, sf.Name)
+		}
+	}
+
+	for _, option := range options {
+		key, value, _ := strings.Cut(strings.TrimSpace(option), "=")
+
+		switch key {
+		case "indexed":
+			field.Indexed = true
+		case "stored":
+			// stored text stays searchable, like CREATE TABLE without options
+			field.Indexed = true
+			field.Stored = true
+		case "stored_only":
+			field.Stored = true
+		case "attribute":
+		case TableEngineColumnar, TableEngineRowwise:
+			field.Engine = key
+		case "dims":
+			dims, err := strconv.Atoi(value)
+			if err != nil {
+				return mcTagField{}, fmt.Errorf("invalid dims of field %s: %s", sf.Name, value)
+			}
+			field.KnnDims = dims
+		case "similarity":
+			field.HnswSimilarity = value
+		case "knn":
+			field.KnnType = value
+		default:
+			return mcTagField{}, fmt.Errorf("unknown option %q of field %s", key, sf.Name)
+		}
+	}
+
+	// text without options: indexed and stored
+	if field.Type == FieldTypeText && !field.Indexed && !field.Stored {
+		field.Indexed = true
+		field.Stored = true
+	}
+
+	return mcTagField{field: field}, nil
+}
+
+func isFieldType(s string) bool {
+	switch s {
+	case FieldTypeText, FieldTypeInt, FieldTypeBigint, FieldTypeFloat, FieldTypeBool, FieldTypeTimestamp,
+		FieldTypeString, FieldTypeJson, FieldTypeMulti, FieldTypeMulti64, FieldTypeFloatVector:
+		return true
+	}
+
+	return false
+}
+
+func fieldTypeOf(t reflect.Type) string {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == timeType {
+		return FieldTypeTimestamp
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return FieldTypeText
+	case reflect.Bool:
+		return FieldTypeBool
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return FieldTypeInt
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		return FieldTypeBigint
+	case reflect.Float32, reflect.Float64:
+		return FieldTypeFloat
+	case reflect.Map, reflect.Struct:
+		return FieldTypeJson
+	case reflect.Slice, reflect.Array:
+		switch t.Elem().Kind() {
+		case reflect.Uint32, reflect.Int32:
+			return FieldTypeMulti
+		case reflect.Uint64, reflect.Int64:
+			return FieldTypeMulti64
+		case reflect.Float32:
+			return FieldTypeFloatVector
+		}
+
+		return FieldTypeJson
+	}
+
+	return ""
+}
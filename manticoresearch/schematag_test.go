@@ -0,0 +1,173 @@
+package manticoresearch
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type schemaTagBase struct {
+	CreatedAt time.Time `mc:"created_at"`
+}
+
+type schemaTagProduct struct {
+	schemaTagBase
+
+	ID       uint64            `mc:"id"`
+	Title    string            `mc:"title,text"`
+	Body     string            `mc:"body,text,indexed"`
+	Summary  string            `mc:"summary,text,stored"`
+	Raw      string            `mc:"raw,text,stored_only"`
+	Brand    string            `mc:"brand,string,indexed"`
+	Price    float64           `mc:"price,columnar"`
+	Stock    int32             `mc:"stock"`
+	Views    int64             `mc:"views"`
+	Tags     []uint64          `mc:"tags"`
+	Labels   []uint32          `mc:"labels"`
+	Meta     map[string]string `mc:"meta"`
+	Vector   []float32         `mc:"vector,float_vector,dims=4,similarity=cosine"`
+	Note     *string           `mc:"note,string"`
+	Ignored  string            `mc:"-"`
+	Untagged string
+}
+
+func TestSchemaFromStruct(t *testing.T) {
+	schema, err := SchemaFromStruct("products", schemaTagProduct{})
+	if err != nil {
+		t.Fatalf("SchemaFromStruct() error = %v", err)
+	}
+
+	want := []TableField{
+		{Name: "created_at", Type: FieldTypeTimestamp},
+		{Name: "title", Type: FieldTypeText, Indexed: true, Stored: true},
+		{Name: "body", Type: FieldTypeText, Indexed: true},
+		{Name: "summary", Type: FieldTypeText, Indexed: true, Stored: true},
+		{Name: "raw", Type: FieldTypeText, Stored: true},
+		{Name: "brand", Type: FieldTypeString, Indexed: true},
+		{Name: "price", Type: FieldTypeFloat, Engine: TableEngineColumnar},
+		{Name: "stock", Type: FieldTypeInt},
+		{Name: "views", Type: FieldTypeBigint},
+		{Name: "tags", Type: FieldTypeMulti64},
+		{Name: "labels", Type: FieldTypeMulti},
+		{Name: "meta", Type: FieldTypeJson},
+		{Name: "vector", Type: FieldTypeFloatVector, KnnDims: 4, HnswSimilarity: "cosine"},
+		{Name: "note", Type: FieldTypeString},
+	}
+
+	if !reflect.DeepEqual(schema.Fields, want) {
+		t.Errorf("SchemaFromStruct() fields =\n%#v\nwant\n%#v", schema.Fields, want)
+	}
+}
+
+func TestSchemaFromStructErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		v    interface{}
+	}{
+		{"not a struct", "products"},
+		{"unknown option", struct {
+			Title string `mc:"title,text,fast"`
+		}{}},
+		{"invalid dims", struct {
+			Vector []float32 `mc:"vector,float_vector,dims=x"`
+		}{}},
+		{"unmapped type", struct {
+			Done chan bool `mc:"done"`
+		}{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := SchemaFromStruct("products", tt.v); err == nil {
+				t.Errorf("SchemaFromStruct() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestEncodeDocument(t *testing.T) {
+	note := "limited"
+	created := time.Unix(1700000000, 0)
+
+	tests := []struct {
+		name    string
+		v       interface{}
+		wantID  uint64
+		wantDoc map[string]interface{}
+		wantErr bool
+	}{
+		{
+			name: "struct pointer",
+			v: &schemaTagProduct{
+				schemaTagBase: schemaTagBase{CreatedAt: created},
+				ID:            18446744073709551615,
+				Title:         "shoe",
+				Price:         9.5,
+				Tags:          []uint64{1, 2},
+				Note:          &note,
+				Ignored:       "x",
+			},
+			wantID: 18446744073709551615,
+			wantDoc: map[string]interface{}{
+				"created_at": created.Unix(),
+				"title":      "shoe",
+				"body":       "",
+				"summary":    "",
+				"raw":        "",
+				"brand":      "",
+				"price":      9.5,
+				"stock":      int32(0),
+				"views":      int64(0),
+				"tags":       []uint64{1, 2},
+				"labels":     []uint32(nil),
+				"meta":       map[string]string(nil),
+				"vector":     []float32(nil),
+				"note":       "limited",
+			},
+		},
+		{
+			name: "zero time and nil pointer",
+			v: struct {
+				ID   int        `mc:"id"`
+				At   time.Time  `mc:"at"`
+				Note *string    `mc:"note"`
+				Opt  *time.Time `mc:"opt"`
+			}{ID: 7},
+			wantID:  7,
+			wantDoc: map[string]interface{}{"at": 0, "note": nil, "opt": nil},
+		},
+		{
+			name: "non integer id",
+			v: struct {
+				ID string `mc:"id"`
+			}{ID: "a"},
+			wantErr: true,
+		},
+		{
+			name:    "not a struct",
+			v:       42,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, doc, err := EncodeDocument(tt.v)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("EncodeDocument() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if id != tt.wantID {
+				t.Errorf("EncodeDocument() id = %d, want %d", id, tt.wantID)
+			}
+
+			if !reflect.DeepEqual(doc, tt.wantDoc) {
+				t.Errorf("EncodeDocument() doc =\n%#v\nwant\n%#v", doc, tt.wantDoc)
+			}
+		})
+	}
+}
@@ -20,9 +20,12 @@ type McSearchResponseHits struct {
 
 // Stupid Named Strategy
 type McSearchResponseHitsHits struct {
-	ID_    string                 `json:"_id,omitempty"`     // match id
-	Score_ int                    `json:"_score,omitempty"`  // match weight, calculated by ranker
-	Source McSearchResponseSource `json:"_source,omitempty"` // an array containing the attributes of this match
+	ID_      string                 `json:"_id,omitempty"`       // match id
+	Score_   int                    `json:"_score,omitempty"`    // match weight, calculated by ranker
+	KnnDist_ float64                `json:"_knn_dist,omitempty"` // knn search: distance to query vector
+	Source   McSearchResponseSource `json:"_source,omitempty"`   // an array containing the attributes of this match
+
+	Highlight map[string][]string `json:"highlight,omitempty"` // highlighted snippets per field
 }
 
 // Source Struct
@@ -72,6 +75,9 @@ type McSearchResponseSource struct {
 
 	// Medias
 	Thumb bool `json:"thumb,omitempty" redis:"thumb"`
+
+	// Geo: computed distance in meters (AddGeoDistExp)
+	GeoDist float64 `json:"geodist,omitempty" redis:"geodist"`
 }
 
 // Aggregations Struct
@@ -0,0 +1,91 @@
+package manticoresearch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+/*
+File Snapshot (FREEZE / UNFREEZE)
+Via: https://manual.manticoresearch.com/Securing_and_compacting_a_table/Freezing_a_table
+
+FREEZE flushes RAM chunks, stops compaction and returns the files of the tables:
+-> FREEZE products
+
++-------------------------------------+---------------------------------------------------+
+| file                                | normalized                                        |
++-------------------------------------+---------------------------------------------------+
+| data/products/products.0.spa        | /var/lib/manticore/data/products/products.0.spa   |
+| data/products/products.meta         | /var/lib/manticore/data/products/products.meta    |
++-------------------------------------+---------------------------------------------------+
+
+The files stay consistent until UNFREEZE: copy them in fn (rsync, tar, volume snapshot...).
+Inserts and updates are still accepted while frozen, they are kept in RAM and binlog.
+*/
+const snapshotUnfreezeTimeout = 30 * time.Second
+
+// Freeze tables, pass their files to fn and unfreeze, also on error or panic of fn
+func (m *ManticoreClient) SnapshotTables(ctx context.Context, tableNames []string, fn func(files []string) error) (err error) {
+	if m.IsReadOnly() {
+		return errors.New("readonly mode active")
+	}
+
+	if len(tableNames) == 0 {
+		return errors.New("tables required")
+	}
+
+	for _, tableName := range tableNames {
+		if !sqlIdentifier.MatchString(tableName) {
+			return fmt.Errorf("invalid table name: %q", tableName)
+		}
+	}
+
+	tables := strings.Join(tableNames, ",")
+
+	resp, err := m.RunCliContext(ctx, []byte(fmt.Sprintf("FREEZE %s", tables)))
+
+	// unfreeze even if FREEZE failed half way: UNFREEZE of a not frozen table is a no-op
+	defer func() {
+		// ctx may be already cancelled
+		unfreezeCtx, cancel := context.WithTimeout(context.Background(), snapshotUnfreezeTimeout)
+		defer cancel()
+
+		if _, uerr := m.RunCliContext(unfreezeCtx, []byte(fmt.Sprintf("UNFREEZE %s", tables))); uerr != nil && err == nil {
+			err = fmt.Errorf("unfreeze: %w", uerr)
+		}
+	}()
+
+	if err != nil {
+		return err
+	}
+
+	files := snapshotFiles(*resp)
+	if len(files) == 0 {
+		return errors.New("empty FREEZE response")
+	}
+
+	return fn(files)
+}
+
+// Absolute paths, without duplicates
+func snapshotFiles(resp MCDocumentMainResponse) []string {
+	files := []string{}
+	seen := map[string]bool{}
+
+	for _, row := range resp.Rows() {
+		file := rowString(row, "normalized")
+		if file == "" {
+			file = rowString(row, "file")
+		}
+
+		if file != "" && !seen[file] {
+			seen[file] = true
+			files = append(files, file)
+		}
+	}
+
+	return files
+}
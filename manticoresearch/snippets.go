@@ -0,0 +1,225 @@
+package manticoresearch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+/*
+CALL SNIPPETS
+Via: https://manual.manticoresearch.com/Searching/Highlighting#CALL-SNIPPETS
+
+Builds excerpts from text which is not stored in the table (e.g. lives in the primary database).
+Table settings (charset_table, morphology etc.) are used to tokenize documents and query:
+-> CALL SNIPPETS(('first document text', 'second document text'), 'table', 'query words', 5 as around, 200 as limit)
+
+Returns one snippet per document, in the same order.
+*/
+const (
+	McSnippetsPassageBoundarySentence  = "sentence"
+	McSnippetsPassageBoundaryParagraph = "paragraph"
+	McSnippetsPassageBoundaryZone      = "zone"
+)
+
+// Numeric options are pointers: 0 is a meaningful value (e.g. limit=0 means no limit)
+type McSnippetsOptions struct {
+	BeforeMatch      string // Default: <b>
+	AfterMatch       string // Default: </b>
+	ChunkSeparator   string // Default: " ... "
+	FieldSeparator   string // Default: "|"
+	HtmlStripMode    string // none, strip, index, retain. Default: index
+	PassageBoundary  string // sentence, paragraph, zone
+	StartPassageId   *int   // starting value of %PASSAGE_ID% macro. Default: 1
+	Limit            *int   // maximum snippet size, in symbols. Default: 256
+	LimitPassages    *int   // maximum number of passages. Default: 0 (no limit)
+	LimitWords       *int   // maximum number of words. Default: 0 (no limit)
+	Around           *int   // words around each matching keyword block. Default: 5
+	ExactPhrase      bool   // highlight exact query phrase matches only
+	UseBoundaries    bool   // additionally break passages by phrase_boundary characters
+	WeightOrder      bool   // sort passages by relevance instead of document order
+	QueryMode        bool   // treat query as full-text query syntax
+	ForceAllWords    bool   // ignore snippet length limit until all keywords are included
+	AllowEmpty       bool   // return empty snippet instead of the beginning of the text when no match
+	EmitZones        bool   // emit html tag of enclosing zone before each passage
+	ForcePassages    bool   // generate passages even if limit allows whole text
+	LoadFiles        bool   // documents are file names
+	LoadFilesScatter bool   // ignore missing files in load_files mode
+}
+
+func NewMcSnippetsOptions() McSnippetsOptions {
+	return McSnippetsOptions{}
+}
+
+func (qb McSnippetsOptions) AddTags(before string, after string) McSnippetsOptions {
+	qb.BeforeMatch = before
+	qb.AfterMatch = after
+
+	return qb
+}
+
+func (qb McSnippetsOptions) AddChunkSeparator(separator string) McSnippetsOptions {
+	qb.ChunkSeparator = separator
+
+	return qb
+}
+
+func (qb McSnippetsOptions) AddFieldSeparator(separator string) McSnippetsOptions {
+	qb.FieldSeparator = separator
+
+	return qb
+}
+
+func (qb McSnippetsOptions) AddHtmlStripMode(mode string) McSnippetsOptions {
+	qb.HtmlStripMode = mode
+
+	return qb
+}
+
+func (qb McSnippetsOptions) AddPassageBoundary(boundary string) McSnippetsOptions {
+	qb.PassageBoundary = boundary
+
+	return qb
+}
+
+func (qb McSnippetsOptions) AddStartPassageId(id int) McSnippetsOptions {
+	qb.StartPassageId = &id
+
+	return qb
+}
+
+func (qb McSnippetsOptions) AddLimit(limit int) McSnippetsOptions {
+	qb.Limit = &limit
+
+	return qb
+}
+
+func (qb McSnippetsOptions) AddLimitPassages(limit int) McSnippetsOptions {
+	qb.LimitPassages = &limit
+
+	return qb
+}
+
+func (qb McSnippetsOptions) AddLimitWords(limit int) McSnippetsOptions {
+	qb.LimitWords = &limit
+
+	return qb
+}
+
+func (qb McSnippetsOptions) AddAround(around int) McSnippetsOptions {
+	qb.Around = &around
+
+	return qb
+}
+
+func (qb McSnippetsOptions) AddQueryMode(enabled bool) McSnippetsOptions {
+	qb.QueryMode = enabled
+
+	return qb
+}
+
+func (qb McSnippetsOptions) AddWeightOrder(enabled bool) McSnippetsOptions {
+	qb.WeightOrder = enabled
+
+	return qb
+}
+
+func (qb McSnippetsOptions) AddAllowEmpty(enabled bool) McSnippetsOptions {
+	qb.AllowEmpty = enabled
+
+	return qb
+}
+
+func (qb McSnippetsOptions) AddForceAllWords(enabled bool) McSnippetsOptions {
+	qb.ForceAllWords = enabled
+
+	return qb
+}
+
+// SQL option list: 5 as around, '<b>' as before_match
+func (qb McSnippetsOptions) args() []string {
+	args := []string{}
+
+	for _, opt := range []struct {
+		name  string
+		value string
+	}{
+		{"before_match", qb.BeforeMatch},
+		{"after_match", qb.AfterMatch},
+		{"chunk_separator", qb.ChunkSeparator},
+		{"field_separator", qb.FieldSeparator},
+		{"html_strip_mode", qb.HtmlStripMode},
+		{"passage_boundary", qb.PassageBoundary},
+	} {
+		if opt.value != "" {
+			args = append(args, fmt.Sprintf("%s as %s", sqlQuote(opt.value), opt.name))
+		}
+	}
+
+	for _, opt := range []struct {
+		name  string
+		value *int
+	}{
+		{"start_passage_id", qb.StartPassageId},
+		{"limit", qb.Limit},
+		{"limit_passages", qb.LimitPassages},
+		{"limit_words", qb.LimitWords},
+		{"around", qb.Around},
+	} {
+		if opt.value != nil {
+			args = append(args, fmt.Sprintf("%d as %s", *opt.value, opt.name))
+		}
+	}
+
+	for _, opt := range []struct {
+		name  string
+		value bool
+	}{
+		{"exact_phrase", qb.ExactPhrase},
+		{"use_boundaries", qb.UseBoundaries},
+		{"weight_order", qb.WeightOrder},
+		{"query_mode", qb.QueryMode},
+		{"force_all_words", qb.ForceAllWords},
+		{"allow_empty", qb.AllowEmpty},
+		{"emit_zones", qb.EmitZones},
+		{"force_passages", qb.ForcePassages},
+		{"load_files", qb.LoadFiles},
+		{"load_files_scattered", qb.LoadFilesScatter},
+	} {
+		if opt.value {
+			args = append(args, fmt.Sprintf("1 as %s", opt.name))
+		}
+	}
+
+	return args
+}
+
+func (m *ManticoreClient) BuildSnippets(ctx context.Context, tableName string, docs []string, query string, opt McSnippetsOptions) ([]string, error) {
+	if len(docs) == 0 {
+		return []string{}, nil
+	}
+
+	args := []string{
+		fmt.Sprintf("(%s)", sqlQuoteList(docs)),
+		sqlQuote(tableName),
+		sqlQuote(query),
+	}
+	args = append(args, opt.args()...)
+
+	resp, err := m.RunCliContext(ctx, []byte(fmt.Sprintf("CALL SNIPPETS(%s)", strings.Join(args, ", "))))
+	if err != nil {
+		return nil, err
+	}
+
+	snippets := []string{}
+	for _, row := range resp.Rows() {
+		snippets = append(snippets, rowString(row, "snippet"))
+	}
+
+	if len(snippets) != len(docs) {
+		return snippets, errors.New("snippet count does not match document count")
+	}
+
+	return snippets, nil
+}
@@ -0,0 +1,126 @@
+package manticoresearch
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SQL Helpers
+
+// Quote string literal for SQL statements: it's -> 'it\'s'
+func sqlQuote(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+
+	return fmt.Sprintf("'%s'", r.Replace(s))
+}
+
+// Quote list of string literals: 'a','b'
+func sqlQuoteList(items []string) string {
+	quoted := make([]string, 0, len(items))
+	for _, item := range items {
+		quoted = append(quoted, sqlQuote(item))
+	}
+
+	return strings.Join(quoted, ",")
+}
+
+// Rows of all result sets: [{"columns": [...], "data": [{"col": val}]}]
+func (r MCDocumentMainResponse) Rows() []map[string]interface{} {
+	rows := []map[string]interface{}{}
+
+	for _, set := range r {
+		data, ok := set.Data.([]interface{})
+		if !ok {
+			continue
+		}
+
+		for _, item := range data {
+			if row, ok := item.(map[string]interface{}); ok {
+				rows = append(rows, row)
+			}
+		}
+	}
+
+	return rows
+}
+
+// First existing column of row, case insensitive: column names differ between server versions
+func rowKey(row map[string]interface{}, names ...string) string {
+	for _, name := range names {
+		if _, ok := row[name]; ok {
+			return name
+		}
+
+		for key := range row {
+			if strings.EqualFold(key, name) {
+				return key
+			}
+		}
+	}
+
+	return ""
+}
+
+// Row values are returned as json numbers or strings, depends on column type
+func rowString(row map[string]interface{}, key string) string {
+	switch v := row[key].(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+}
+
+func rowInt64(row map[string]interface{}, key string) int64 {
+	switch v := row[key].(type) {
+	case float64:
+		return int64(v)
+	case string:
+		i, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+		if err != nil {
+			return int64(parseFloat(v))
+		}
+
+		return i
+	}
+
+	return 0
+}
+
+func rowUint64(row map[string]interface{}, key string) uint64 {
+	switch v := row[key].(type) {
+	case float64:
+		return uint64(v)
+	case string:
+		u, _ := strconv.ParseUint(strings.TrimSpace(v), 10, 64)
+		return u
+	}
+
+	return 0
+}
+
+func rowFloat64(row map[string]interface{}, key string) float64 {
+	switch v := row[key].(type) {
+	case float64:
+		return v
+	case string:
+		return parseFloat(v)
+	}
+
+	return 0
+}
+
+func parseFloat(s string) float64 {
+	f, _ := strconv.ParseFloat(strings.TrimSpace(s), 64)
+
+	return f
+}
@@ -0,0 +1,247 @@
+package manticoresearch
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/*
+Server Status, Threads and Queries
+Via: https://manual.manticoresearch.com/Node_info_and_management/Node_status
+
+-> SHOW STATUS
++-----------------+--------+
+| Counter         | Value  |
++-----------------+--------+
+| uptime          | 1842   |
+| connections     | 103    |
+| queries         | 2912   |
+| avg_query_wall  | 0.004  |
+| workers_active  | 2      |
++-----------------+--------+
+
+-> SHOW THREADS
++------+--------+-------+-------+-----------------+--------------------+-----------+------------------------+
+| TID  | Name   | Proto | State | Connection from | This/prev job time | Jobs done | Info                   |
++------+--------+-------+-------+-----------------+--------------------+-----------+------------------------+
+| 1274 | work_1 | http  | query | 127.0.0.1:52046 | 5ms                | 103       | select * from products |
++------+--------+-------+-------+-----------------+--------------------+-----------+------------------------+
+
+-> SHOW QUERIES
++----+-------------------------+------+----------+-----------------+
+| id | query                   | time | protocol | host            |
++----+-------------------------+------+----------+-----------------+
+| 11 | select * from products  | 2s   | http     | 127.0.0.1:34512 |
++----+-------------------------+------+----------+-----------------+
+
+Column names changed between server versions, typed rows accept known aliases.
+*/
+type ServerStatus struct {
+	Uptime       time.Duration
+	Version      string
+	MysqlVersion string
+
+	Connections int64
+	MaxedOut    int64
+
+	Queries     int64
+	DistQueries int64
+
+	CommandSearch  int64
+	CommandInsert  int64
+	CommandReplace int64
+	CommandUpdate  int64
+	CommandDelete  int64
+	CommandCommit  int64
+
+	AgentConnect int64
+	AgentRetry   int64
+
+	WorkersTotal    int64
+	WorkersActive   int64
+	WorkersClients  int64
+	WorkQueueLength int64
+
+	QueryWall    float64 // seconds, total
+	AvgQueryWall float64 // seconds
+	QueryCpu     float64 // seconds, total (needs --cputimes)
+	AvgQueryCpu  float64 // seconds
+	DistWall     float64 // seconds, total
+	AvgDistWall  float64 // seconds
+
+	// All counters
+	Counters map[string]string
+}
+
+type ThreadInfo struct {
+	Tid      int64
+	Name     string
+	Proto    string
+	State    string
+	Host     string
+	ConnID   int64
+	Time     time.Duration // current or previous job time
+	JobsDone int64
+	Info     string // running statement
+}
+
+type RunningQuery struct {
+	ID       int64
+	Query    string
+	Time     time.Duration
+	Protocol string
+	Host     string
+}
+
+// Typed SHOW STATUS
+func (m *ManticoreClient) GetServerStatus(ctx context.Context) (*ServerStatus, error) {
+	resp, err := m.RunCliContext(ctx, []byte("SHOW STATUS"))
+	if err != nil {
+		return nil, err
+	}
+
+	status := &ServerStatus{
+		Counters: map[string]string{},
+	}
+
+	for _, row := range resp.Rows() {
+		status.Counters[rowString(row, "Counter")] = rowString(row, "Value")
+	}
+
+	c := status.Counters
+	counter := func(key string) int64 {
+		v, _ := strconv.ParseInt(strings.TrimSpace(c[key]), 10, 64)
+		return v
+	}
+
+	status.Uptime = time.Duration(counter("uptime")) * time.Second
+	status.Version = c["version"]
+	status.MysqlVersion = c["mysql_version"]
+	status.Connections = counter("connections")
+	status.MaxedOut = counter("maxed_out")
+	status.Queries = counter("queries")
+	status.DistQueries = counter("dist_queries")
+	status.CommandSearch = counter("command_search")
+	status.CommandInsert = counter("command_insert")
+	status.CommandReplace = counter("command_replace")
+	status.CommandUpdate = counter("command_update")
+	status.CommandDelete = counter("command_delete")
+	status.CommandCommit = counter("command_commit")
+	status.AgentConnect = counter("agent_connect")
+	status.AgentRetry = counter("agent_retry")
+	status.WorkersTotal = counter("workers_total")
+	status.WorkersActive = counter("workers_active")
+	status.WorkersClients = counter("workers_clients")
+	status.WorkQueueLength = counter("work_queue_length")
+
+	// "OFF" when cpu times are disabled
+	status.QueryWall = parseFloat(c["query_wall"])
+	status.AvgQueryWall = parseFloat(c["avg_query_wall"])
+	status.QueryCpu = parseFloat(c["query_cpu"])
+	status.AvgQueryCpu = parseFloat(c["avg_query_cpu"])
+	status.DistWall = parseFloat(c["dist_wall"])
+	status.AvgDistWall = parseFloat(c["avg_dist_wall"])
+
+	return status, nil
+}
+
+// Typed SHOW THREADS
+func (m *ManticoreClient) GetThreads(ctx context.Context) ([]ThreadInfo, error) {
+	resp, err := m.RunCliContext(ctx, []byte("SHOW THREADS"))
+	if err != nil {
+		return nil, err
+	}
+
+	threads := []ThreadInfo{}
+	for _, row := range resp.Rows() {
+		// unknown time format: keep the thread with zero time
+		jobTime, err := parseDuration(rowString(row, rowKey(row, "This/prev job time", "Time")))
+		if err != nil && m.client.debug {
+			fmt.Printf("SHOW THREADS: %s\n", err)
+		}
+
+		threads = append(threads, ThreadInfo{
+			Tid:      rowInt64(row, rowKey(row, "TID", "Tid")),
+			Name:     rowString(row, rowKey(row, "Name")),
+			Proto:    rowString(row, rowKey(row, "Proto")),
+			State:    rowString(row, rowKey(row, "State")),
+			Host:     rowString(row, rowKey(row, "Connection from", "Host")),
+			ConnID:   rowInt64(row, rowKey(row, "ConnID")),
+			Time:     jobTime,
+			JobsDone: rowInt64(row, rowKey(row, "Jobs done")),
+			Info:     rowString(row, rowKey(row, "Info")),
+		})
+	}
+
+	return threads, nil
+}
+
+// Typed SHOW QUERIES: running queries of all protocols
+func (m *ManticoreClient) GetQueries(ctx context.Context) ([]RunningQuery, error) {
+	resp, err := m.RunCliContext(ctx, []byte("SHOW QUERIES"))
+	if err != nil {
+		return nil, err
+	}
+
+	queries := []RunningQuery{}
+	for _, row := range resp.Rows() {
+		// unknown time format: keep the query with zero time
+		queryTime, err := parseDuration(rowString(row, rowKey(row, "time")))
+		if err != nil && m.client.debug {
+			fmt.Printf("SHOW QUERIES: %s\n", err)
+		}
+
+		queries = append(queries, RunningQuery{
+			ID:       rowInt64(row, rowKey(row, "id")),
+			Query:    rowString(row, rowKey(row, "query")),
+			Time:     queryTime,
+			Protocol: rowString(row, rowKey(row, "protocol", "proto")),
+			Host:     rowString(row, rowKey(row, "host")),
+		})
+	}
+
+	return queries, nil
+}
+
+// Server durations: 5ms, 1.2s, 30us, 2h 1m, 1d 2h, "5ms ago", plain number: seconds. Empty or "-": 0
+func parseDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if trimmed, ok := strings.CutSuffix(s, "ago"); ok {
+		s = strings.TrimSpace(trimmed)
+	}
+	if s == "" || s == "-" {
+		return 0, nil
+	}
+
+	if seconds, err := strconv.ParseFloat(s, 64); err == nil {
+		return time.Duration(seconds * float64(time.Second)), nil
+	}
+
+	var total time.Duration
+	for _, part := range strings.Fields(s) {
+		// time.ParseDuration has no day unit: "1d", "1d2h"
+		if days, rest, ok := strings.Cut(part, "d"); ok {
+			n, err := strconv.ParseFloat(days, 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid duration %q", s)
+			}
+
+			total += time.Duration(n * float64(24*time.Hour))
+			if part = rest; part == "" {
+				continue
+			}
+		}
+
+		d, err := time.ParseDuration(part)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+
+		total += d
+	}
+
+	return total, nil
+}
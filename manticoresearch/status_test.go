@@ -0,0 +1,45 @@
+package manticoresearch
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"-", 0, false},
+		{"5ms", 5 * time.Millisecond, false},
+		{"30us", 30 * time.Microsecond, false},
+		{"1.2s", 1200 * time.Millisecond, false},
+		{"2h 1m", 2*time.Hour + time.Minute, false},
+		{"1d 2h", 26 * time.Hour, false},
+		{"1d2h", 26 * time.Hour, false},
+		{"3d", 72 * time.Hour, false},
+		{"5ms ago", 5 * time.Millisecond, false},
+		{"255us", 255 * time.Microsecond, false},
+		{"1h 2m ago", time.Hour + 2*time.Minute, false},
+		{"0.5", 500 * time.Millisecond, false},
+		{" 12 ", 12 * time.Second, false},
+		{"xd", 0, true},
+		{"1 fortnight", 0, true},
+		{"soon", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := parseDuration(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseDuration(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("parseDuration(%q) = %s, want %s", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package manticoresearch
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+/*
+Spell Correction
+Via: https://manual.manticoresearch.com/Searching/Spell_correction
+
+Requires min_infix_len and dict=keywords.
+
+CALL SUGGEST suggests corrections for a single word:
+-> CALL SUGGEST('crossb', 'table', 5 as limit, 4 as max_edits)
+
+CALL QSUGGEST suggests corrections for the last word of a sentence:
+-> CALL QSUGGEST('hello wrld', 'table', 1 as sentence)
+*/
+type McSuggestOptions struct {
+	Limit       int   // number of suggestions. Default: 5
+	MaxEdits    int   // max Levenshtein distance. Default: 4
+	DeltaLen    int   // max length difference. Default: 3
+	MaxMatches  int   // number of candidates to check. Default: 25
+	ResultStats *bool // return distance and docs columns. Default: 1
+	Sentence    bool  // return the original sentence with the last word replaced
+}
+
+func NewMcSuggestOptions() McSuggestOptions {
+	return McSuggestOptions{}
+}
+
+func (qb McSuggestOptions) AddLimit(limit int) McSuggestOptions {
+	qb.Limit = limit
+
+	return qb
+}
+
+func (qb McSuggestOptions) AddMaxEdits(edits int) McSuggestOptions {
+	qb.MaxEdits = edits
+
+	return qb
+}
+
+func (qb McSuggestOptions) AddDeltaLen(length int) McSuggestOptions {
+	qb.DeltaLen = length
+
+	return qb
+}
+
+func (qb McSuggestOptions) AddMaxMatches(matches int) McSuggestOptions {
+	qb.MaxMatches = matches
+
+	return qb
+}
+
+func (qb McSuggestOptions) AddResultStats(enabled bool) McSuggestOptions {
+	qb.ResultStats = &enabled
+
+	return qb
+}
+
+func (qb McSuggestOptions) AddSentence(enabled bool) McSuggestOptions {
+	qb.Sentence = enabled
+
+	return qb
+}
+
+type McSuggestion struct {
+	Suggest  string
+	Distance int64
+	Docs     int64
+}
+
+func (m *ManticoreClient) Suggest(ctx context.Context, tableName string, word string, opt McSuggestOptions) ([]McSuggestion, error) {
+	return m.callSuggest(ctx, "SUGGEST", tableName, word, opt)
+}
+
+func (m *ManticoreClient) QSuggest(ctx context.Context, tableName string, sentence string, opt McSuggestOptions) ([]McSuggestion, error) {
+	return m.callSuggest(ctx, "QSUGGEST", tableName, sentence, opt)
+}
+
+func (m *ManticoreClient) callSuggest(ctx context.Context, call string, tableName string, word string, opt McSuggestOptions) ([]McSuggestion, error) {
+	args := []string{sqlQuote(word), sqlQuote(tableName)}
+
+	if opt.Limit > 0 {
+		args = append(args, fmt.Sprintf("%d as limit", opt.Limit))
+	}
+
+	if opt.MaxEdits > 0 {
+		args = append(args, fmt.Sprintf("%d as max_edits", opt.MaxEdits))
+	}
+
+	if opt.DeltaLen > 0 {
+		args = append(args, fmt.Sprintf("%d as delta_len", opt.DeltaLen))
+	}
+
+	if opt.MaxMatches > 0 {
+		args = append(args, fmt.Sprintf("%d as max_matches", opt.MaxMatches))
+	}
+
+	if opt.ResultStats != nil {
+		args = append(args, fmt.Sprintf("%d as result_stats", boolInt(*opt.ResultStats)))
+	}
+
+	if opt.Sentence {
+		args = append(args, "1 as sentence")
+	}
+
+	resp, err := m.RunCliContext(ctx, []byte(fmt.Sprintf("CALL %s(%s)", call, strings.Join(args, ", "))))
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions := []McSuggestion{}
+	for _, row := range resp.Rows() {
+		suggestions = append(suggestions, McSuggestion{
+			Suggest:  rowString(row, "suggest"),
+			Distance: rowInt64(row, "distance"),
+			Docs:     rowInt64(row, "docs"),
+		})
+	}
+
+	return suggestions, nil
+}
+
+/*
+Did You Mean
+
+Rewrites every plain word of the query with its top suggestion. Use it when a search returns zero hits:
+
+	resp, _ := client.Search(builder)
+	if resp.Hits.Total == 0 {
+		if fixed, changed, _ := client.DidYouMean(ctx, "products", query); changed {
+			// "Did you mean: fixed?"
+		}
+	}
+
+Words containing query operators (@field, "phrase", -not, wild*) are kept as is.
+*/
+func (m *ManticoreClient) DidYouMean(ctx context.Context, tableName string, query string) (string, bool, error) {
+	words := strings.Fields(query)
+	changed := false
+
+	opt := NewMcSuggestOptions().AddLimit(1)
+	for i, word := range words {
+		if !isPlainWord(word) {
+			continue
+		}
+
+		suggestions, err := m.Suggest(ctx, tableName, word, opt)
+		if err != nil {
+			return query, false, err
+		}
+
+		if len(suggestions) == 0 || suggestions[0].Distance == 0 || suggestions[0].Suggest == "" {
+			continue
+		}
+
+		if !strings.EqualFold(suggestions[0].Suggest, word) {
+			words[i] = suggestions[0].Suggest
+			changed = true
+		}
+	}
+
+	if !changed {
+		return query, false, nil
+	}
+
+	return strings.Join(words, " "), true, nil
+}
+
+func isPlainWord(word string) bool {
+	for _, r := range word {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return false
+		}
+	}
+
+	return word != ""
+}
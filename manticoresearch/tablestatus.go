@@ -0,0 +1,288 @@
+package manticoresearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+/*
+Table Status
+Via: https://manual.manticoresearch.com/Node_info_and_management/Table_settings_and_status/SHOW_TABLE_STATUS
+
+-> SHOW TABLE products STATUS
+
++-------------------+----------------------------------------------------------------------------+
+| Variable_name     | Value                                                                      |
++-------------------+----------------------------------------------------------------------------+
+| index_type        | rt                                                                         |
+| indexed_documents | 1200000                                                                    |
+| ram_bytes         | 10842011                                                                   |
+| disk_bytes        | 982301211                                                                  |
+| ram_chunk         | 6201231                                                                    |
+| disk_chunks       | 12                                                                         |
+| mem_limit         | 134217728                                                                  |
+| killed_documents  | 3120                                                                       |
+| query_time_1min   | {"queries":2, "avg_sec":0.001, "min_sec":0.001, "max_sec":0.002, ...}      |
++-------------------+----------------------------------------------------------------------------+
+
+Health checks (TableHealthReport, EvaluateTableHealth):
+- too many disk chunks: searches touch every chunk, run OPTIMIZE
+- RAM chunk near rt_mem_limit: frequent flushes, raise rt_mem_limit
+- high killed documents ratio: deleted/replaced documents still on disk, run OPTIMIZE
+*/
+const (
+	TableHealthCheckDiskChunks = "disk_chunks"
+	TableHealthCheckMemLimit   = "mem_limit"
+	TableHealthCheckKilled     = "killed_documents"
+	TableHealthCheckStatus     = "status"
+)
+
+// Default health thresholds
+const (
+	DefaultTableHealthMaxDiskChunks  = 32
+	DefaultTableHealthMaxMemLimitUse = 0.9
+	DefaultTableHealthMaxKilledRatio = 0.2
+)
+
+// Query time statistics of a time window, in seconds
+type TableQueryStats struct {
+	Queries int64
+	Avg     float64
+	Min     float64
+	Max     float64
+	Pct95   float64
+	Pct99   float64
+}
+
+type TableStatus struct {
+	Name string
+	Type string // rt, plain, percolate
+
+	IndexedDocuments int64
+	IndexedBytes     int64
+	KilledDocuments  int64
+
+	RamBytes         int64
+	DiskBytes        int64
+	DiskMapped       int64
+	DiskMappedCached int64
+	RamChunk         int64
+	RamChunkSegments int64
+	RamBytesRetired  int64
+	DiskChunks       int64
+	MemLimit         int64
+	MemLimitRate     float64 // 0.95: RAM chunk is flushed at 95% of mem_limit
+
+	Optimizing bool
+	Locked     bool
+
+	QueryTime1Min  TableQueryStats
+	QueryTime5Min  TableQueryStats
+	QueryTime15Min TableQueryStats
+	QueryTimeTotal TableQueryStats
+
+	// All variables
+	Variables map[string]string
+}
+
+type TableHealthThresholds struct {
+	MaxDiskChunks  int64   // Default: 32
+	MaxMemLimitUse float64 // RAM chunk / mem_limit. Default: 0.9
+	MaxKilledRatio float64 // killed / (indexed + killed). Default: 0.2
+}
+
+type TableHealthIssue struct {
+	Check   string
+	Message string
+}
+
+type TableHealth struct {
+	Table  string
+	Status *TableStatus
+	Issues []TableHealthIssue
+}
+
+func (h TableHealth) Healthy() bool {
+	return len(h.Issues) == 0
+}
+
+// RAM chunk / mem_limit
+func (s TableStatus) MemLimitUse() float64 {
+	if s.MemLimit <= 0 {
+		return 0
+	}
+
+	return float64(s.RamChunk) / float64(s.MemLimit)
+}
+
+// killed / (indexed + killed)
+func (s TableStatus) KilledRatio() float64 {
+	total := s.IndexedDocuments + s.KilledDocuments
+	if total <= 0 {
+		return 0
+	}
+
+	return float64(s.KilledDocuments) / float64(total)
+}
+
+// Typed SHOW TABLE <name> STATUS
+func (m *ManticoreClient) GetTableStatus(ctx context.Context, tableName string) (*TableStatus, error) {
+	if !sqlIdentifier.MatchString(tableName) {
+		return nil, fmt.Errorf("invalid table name: %q", tableName)
+	}
+
+	resp, err := m.RunCliContext(ctx, []byte(fmt.Sprintf("SHOW TABLE %s STATUS", tableName)))
+	if err != nil {
+		return nil, err
+	}
+
+	status := &TableStatus{
+		Name:      tableName,
+		Variables: map[string]string{},
+	}
+
+	for _, row := range resp.Rows() {
+		status.Variables[rowString(row, "Variable_name")] = rowString(row, "Value")
+	}
+
+	if len(status.Variables) == 0 {
+		return nil, fmt.Errorf("empty status of table %s", tableName)
+	}
+
+	v := status.Variables
+	variable := func(key string) int64 {
+		i, _ := strconv.ParseInt(strings.TrimSpace(v[key]), 10, 64)
+		return i
+	}
+
+	status.Type = v["index_type"]
+	status.IndexedDocuments = variable("indexed_documents")
+	status.IndexedBytes = variable("indexed_bytes")
+	status.KilledDocuments = variable("killed_documents")
+	status.RamBytes = variable("ram_bytes")
+	status.DiskBytes = variable("disk_bytes")
+	status.DiskMapped = variable("disk_mapped")
+	status.DiskMappedCached = variable("disk_mapped_cached")
+	status.RamChunk = variable("ram_chunk")
+	status.RamChunkSegments = variable("ram_chunk_segments_count")
+	status.RamBytesRetired = variable("ram_bytes_retired")
+	status.DiskChunks = variable("disk_chunks")
+	status.MemLimit = variable("mem_limit")
+	status.MemLimitRate = parsePercent(v["mem_limit_rate"])
+	status.Optimizing = variable("optimizing") > 0
+	status.Locked = variable("locked") > 0
+	status.QueryTime1Min = parseTableQueryStats(v["query_time_1min"])
+	status.QueryTime5Min = parseTableQueryStats(v["query_time_5min"])
+	status.QueryTime15Min = parseTableQueryStats(v["query_time_15min"])
+	status.QueryTimeTotal = parseTableQueryStats(v["query_time_total"])
+
+	return status, nil
+}
+
+// Health issues of table status, zero thresholds use defaults
+func EvaluateTableHealth(status TableStatus, th TableHealthThresholds) TableHealth {
+	if th.MaxDiskChunks <= 0 {
+		th.MaxDiskChunks = DefaultTableHealthMaxDiskChunks
+	}
+
+	if th.MaxMemLimitUse <= 0 {
+		th.MaxMemLimitUse = DefaultTableHealthMaxMemLimitUse
+	}
+
+	if th.MaxKilledRatio <= 0 {
+		th.MaxKilledRatio = DefaultTableHealthMaxKilledRatio
+	}
+
+	health := TableHealth{
+		Table:  status.Name,
+		Status: &status,
+		Issues: []TableHealthIssue{},
+	}
+
+	if status.DiskChunks > th.MaxDiskChunks {
+		health.Issues = append(health.Issues, TableHealthIssue{
+			Check:   TableHealthCheckDiskChunks,
+			Message: fmt.Sprintf("%d disk chunks, more than %d: run OPTIMIZE", status.DiskChunks, th.MaxDiskChunks),
+		})
+	}
+
+	if use := status.MemLimitUse(); use >= th.MaxMemLimitUse {
+		health.Issues = append(health.Issues, TableHealthIssue{
+			Check:   TableHealthCheckMemLimit,
+			Message: fmt.Sprintf("RAM chunk uses %.0f%% of rt_mem_limit (%d bytes)", use*100, status.MemLimit),
+		})
+	}
+
+	if ratio := status.KilledRatio(); ratio > th.MaxKilledRatio {
+		health.Issues = append(health.Issues, TableHealthIssue{
+			Check:   TableHealthCheckKilled,
+			Message: fmt.Sprintf("%.0f%% of documents are killed (%d): run OPTIMIZE", ratio*100, status.KilledDocuments),
+		})
+	}
+
+	return health
+}
+
+// Health of all local tables from SHOW TABLES, distributed tables are skipped
+func (m *ManticoreClient) TableHealthReport(ctx context.Context, th TableHealthThresholds) ([]TableHealth, error) {
+	resp, err := m.RunCliContext(ctx, []byte("SHOW TABLES"))
+	if err != nil {
+		return nil, err
+	}
+
+	report := []TableHealth{}
+	for _, row := range resp.Rows() {
+		name := rowString(row, rowKey(row, "Index", "Table"))
+		if name == "" || strings.EqualFold(rowString(row, "Type"), TableTypeDistributed) {
+			continue
+		}
+
+		status, err := m.GetTableStatus(ctx, name)
+		if err != nil {
+			if ctx.Err() != nil {
+				return report, ctx.Err()
+			}
+
+			report = append(report, TableHealth{
+				Table:  name,
+				Issues: []TableHealthIssue{{Check: TableHealthCheckStatus, Message: err.Error()}},
+			})
+			continue
+		}
+
+		report = append(report, EvaluateTableHealth(*status, th))
+	}
+
+	return report, nil
+}
+
+// {"queries":2, "avg_sec":0.001, "min_sec":0.001, "max_sec":0.002, "pct95_sec":0.002, "pct99_sec":0.002}
+// values are "-" without queries
+func parseTableQueryStats(s string) TableQueryStats {
+	values := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(s), &values); err != nil {
+		return TableQueryStats{}
+	}
+
+	return TableQueryStats{
+		Queries: rowInt64(values, "queries"),
+		Avg:     rowFloat64(values, "avg_sec"),
+		Min:     rowFloat64(values, "min_sec"),
+		Max:     rowFloat64(values, "max_sec"),
+		Pct95:   rowFloat64(values, "pct95_sec"),
+		Pct99:   rowFloat64(values, "pct99_sec"),
+	}
+}
+
+// "95.00%" -> 0.95
+func parsePercent(s string) float64 {
+	s = strings.TrimSpace(s)
+	if strings.HasSuffix(s, "%") {
+		return parseFloat(strings.TrimSuffix(s, "%")) / 100
+	}
+
+	return parseFloat(s)
+}
@@ -1,6 +1,10 @@
 package manticoresearch
 
-import "encoding/json"
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+)
 
 // Upsert Document
 type MCDocumentUpsertRequest struct {
@@ -28,3 +32,35 @@ func (mc *MCDocumentUpsertRequest) UnmarshalBinary(data []byte) error {
 
 	return nil
 }
+
+/*
+float_vector attribute
+
+Doc can be a map or a struct (converted to map). Vector length must be equal to knn_dims of the attribute.
+*/
+func (mc *MCDocumentUpsertRequest) AddFloatVector(attr string, vector []float32) error {
+	doc := map[string]interface{}{}
+
+	switch d := mc.Doc.(type) {
+	case nil:
+	case map[string]interface{}:
+		doc = d
+	default:
+		data, err := json.Marshal(d)
+		if err != nil {
+			return err
+		}
+
+		// json.Number keeps uint64 ids and big integers exact
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		if err := decoder.Decode(&doc); err != nil {
+			return errors.New("doc must be a json object")
+		}
+	}
+
+	doc[attr] = vector
+	mc.Doc = doc
+
+	return nil
+}
@@ -0,0 +1,247 @@
+package manticoresearch
+
+import (
+	"context"
+	"errors"
+	"log"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+/*
+Slow Query Watchdog
+
+Polls SHOW QUERIES and kills queries running longer than the limit of the first matching policy:
+
+	watchdog := client.NewQueryWatchdog(WatchdogOptions{
+		Interval: time.Second,
+		Policies: []WatchdogPolicy{
+			{Name: "facets", Pattern: regexp.MustCompile(`(?i)facet`), MaxRuntime: 5 * time.Second},
+			{Name: "products", Table: "products", MaxRuntime: 10 * time.Second},
+			{Name: "default", MaxRuntime: 30 * time.Second},
+		},
+		OnEvent: func(e WatchdogEvent) { ... },
+	})
+	watchdog.Start(ctx)
+	defer watchdog.Stop()
+
+Killing needs a client without readonly mode. DryRun reports offenders without killing them.
+*/
+const DefaultWatchdogInterval = 5 * time.Second
+
+// Tables of SQL queries (FROM t1, cluster:t2) and JSON queries ("index":"t1")
+var watchdogTablePattern = regexp.MustCompile(`(?i)(?:\bfrom\s+|"(?:index|table)"\s*:\s*")([A-Za-z0-9_:]+(?:\s*,\s*[A-Za-z0-9_:]+)*)`)
+
+type WatchdogPolicy struct {
+	Name string
+
+	// Empty: any table
+	Table string
+	// Nil: any query
+	Pattern *regexp.Regexp
+
+	MaxRuntime time.Duration
+}
+
+type WatchdogEvent struct {
+	Query  RunningQuery
+	Policy string
+	Killed bool
+	Err    error
+	Time   time.Time
+}
+
+type WatchdogOptions struct {
+	// Poll interval. Default: 5s
+	Interval time.Duration
+
+	// Evaluated in order, first matching policy applies
+	Policies []WatchdogPolicy
+
+	// Report offenders only
+	DryRun bool
+
+	// Event handler. Default: log
+	OnEvent func(WatchdogEvent)
+}
+
+type QueryWatchdog struct {
+	client *ManticoreClient
+	opt    WatchdogOptions
+
+	// killed (or reported in dry run) query ids, not reported twice
+	killed   map[int64]bool
+	checking sync.Mutex
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func (m *ManticoreClient) NewQueryWatchdog(opt WatchdogOptions) *QueryWatchdog {
+	if opt.Interval <= 0 {
+		opt.Interval = DefaultWatchdogInterval
+	}
+
+	if opt.OnEvent == nil {
+		opt.OnEvent = func(e WatchdogEvent) {
+			log.Printf("manticore watchdog: policy=%s query_id=%d time=%s killed=%t err=%v query=%q",
+				e.Policy, e.Query.ID, e.Query.Time, e.Killed, e.Err, e.Query.Query)
+		}
+	}
+
+	return &QueryWatchdog{
+		client: m,
+		opt:    opt,
+		killed: map[int64]bool{},
+	}
+}
+
+// Start polling in background until Stop or ctx is done
+func (w *QueryWatchdog) Start(ctx context.Context) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.cancel != nil {
+		return errors.New("watchdog already started")
+	}
+
+	if !w.opt.DryRun && w.client.IsReadOnly() {
+		return errors.New("readonly mode active")
+	}
+
+	ctx, w.cancel = context.WithCancel(ctx)
+	w.done = make(chan struct{})
+
+	go w.run(ctx, w.done)
+
+	return nil
+}
+
+// Stop polling and wait for the running check
+func (w *QueryWatchdog) Stop() {
+	w.mu.Lock()
+	cancel, done := w.cancel, w.done
+	w.cancel, w.done = nil, nil
+	w.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+		<-done
+	}
+}
+
+func (w *QueryWatchdog) run(ctx context.Context, done chan struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(w.opt.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := w.Check(ctx); err != nil && ctx.Err() == nil && w.client.client.debug {
+			log.Printf("manticore watchdog: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check runs one poll: kills offenders and returns their events
+func (w *QueryWatchdog) Check(ctx context.Context) ([]WatchdogEvent, error) {
+	w.checking.Lock()
+	defer w.checking.Unlock()
+
+	queries, err := w.client.GetQueries(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	events := []WatchdogEvent{}
+	running := map[int64]bool{}
+
+	for _, q := range queries {
+		running[q.ID] = true
+
+		// watchdog itself
+		if strings.HasPrefix(strings.ToUpper(strings.TrimSpace(q.Query)), "SHOW QUERIES") || w.killed[q.ID] {
+			continue
+		}
+
+		policy, ok := w.match(q)
+		if !ok || q.Time <= policy.MaxRuntime {
+			continue
+		}
+
+		event := WatchdogEvent{
+			Query:  q,
+			Policy: policy.Name,
+			Time:   time.Now(),
+		}
+
+		if !w.opt.DryRun {
+			_, event.Err = w.client.KillQueryContext(ctx, int(q.ID))
+			event.Killed = event.Err == nil
+		}
+
+		// failed kills are retried on the next poll
+		if event.Killed || w.opt.DryRun {
+			w.killed[q.ID] = true
+		}
+
+		events = append(events, event)
+		w.opt.OnEvent(event)
+	}
+
+	// forget finished queries, ids are reused
+	for id := range w.killed {
+		if !running[id] {
+			delete(w.killed, id)
+		}
+	}
+
+	return events, nil
+}
+
+// First policy matching table and pattern of query
+func (w *QueryWatchdog) match(q RunningQuery) (WatchdogPolicy, bool) {
+	for _, policy := range w.opt.Policies {
+		if policy.MaxRuntime <= 0 {
+			continue
+		}
+
+		if policy.Pattern != nil && !policy.Pattern.MatchString(q.Query) {
+			continue
+		}
+
+		if policy.Table != "" && !contains(queryTables(q.Query), policy.Table) {
+			continue
+		}
+
+		return policy, true
+	}
+
+	return WatchdogPolicy{}, false
+}
+
+// Table names of query text, cluster prefix removed
+func queryTables(query string) []string {
+	tables := []string{}
+	for _, match := range watchdogTablePattern.FindAllStringSubmatch(query, -1) {
+		for _, name := range strings.Split(match[1], ",") {
+			name = strings.TrimSpace(name)
+			if i := strings.LastIndex(name, ":"); i >= 0 {
+				name = name[i+1:]
+			}
+
+			tables = append(tables, name)
+		}
+	}
+
+	return tables
+}